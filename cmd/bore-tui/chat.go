@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"bore-tui/internal/agents"
+	"bore-tui/internal/config"
+	"bore-tui/internal/process"
+)
+
+const chatUsage = `usage: bore-tui chat [--repo PATH] [--json] <message>
+
+Asks the Commander a one-off question about the cluster, with the same
+context (brain, crews, threads, task history, recent runs) as the chat
+screen. A message of - is read from stdin.`
+
+// runChat implements the "chat" subcommand.
+func runChat(args []string) error {
+	f := newFlags("chat", chatUsage, true)
+	pos, err := f.parse(args, 1, -1)
+	if err != nil {
+		return err
+	}
+	message, err := readText(strings.Join(pos, " "))
+	if err != nil {
+		return fmt.Errorf("chat: %w", err)
+	}
+	if message == "" {
+		return fmt.Errorf("chat: empty message")
+	}
+
+	ctx := context.Background()
+	a, err := openApp(ctx, *f.repo)
+	if err != nil {
+		return fmt.Errorf("chat: %w", err)
+	}
+	defer a.Close()
+
+	cmdCtx, err := agents.LoadCommanderContext(ctx, a.DB(), a.Cluster().ID)
+	if err != nil {
+		return fmt.Errorf("chat: %w", err)
+	}
+	prompt := agents.BuildCommanderChatSystemPrompt(cmdCtx) + "\n\n---\n\n" +
+		agents.BuildCommanderChatMessage(nil, message)
+
+	result := a.Backend(config.RoleCommander).RunWithOptions(ctx, a.Repo().Path, prompt, process.RunOptions{Permissions: process.ReadOnly()})
+	if result.Err != nil {
+		return fmt.Errorf("chat: claude: %w", result.Err)
+	}
+	response := strings.TrimSpace(result.Text)
+	if response == "" {
+		response = "(no response)"
+	}
+
+	if *f.json {
+		return printJSON(map[string]string{"response": response})
+	}
+	fmt.Println(response)
+	return nil
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"bore-tui/internal/app"
+)
+
+const usage = `usage: bore-tui [command] [arguments]
+
+Without a command, bore-tui starts the terminal UI.
+
+Commands:
+  cluster init [path]          initialize a cluster in a git repository
+  cluster open [path]          open a cluster and run crash recovery
+  cluster list                 list known clusters
+  task new                     create a task
+  task list                    list tasks
+  task show <id>               show a task and its executions
+  exec start <task-id>         run a task in the foreground
+  exec resume <exec-id>        resume an interrupted or cancelled execution
+  exec status <exec-id>        show an execution, its agent runs and questions
+  exec logs <exec-id>          print an execution's event log
+  exec diff <exec-id>          print the changes in an execution's worktree
+  exec merge <exec-id>         merge an execution into its base branch
+  exec revert <exec-id>        discard the changes in an execution's worktree
+  chat <message>               ask the Commander a question
+  serve                        run the web GUI without the terminal UI
+  db migrate                   apply pending schema migrations
+  fake-claude                  replay recorded agent runs as the claude CLI
+
+Commands that work on a cluster take --repo <path> (default: the current
+directory). Most take --json to print machine-readable output.
+Run "bore-tui <command> -h" for a command's flags.`
+
+// runCommand dispatches a headless subcommand.
+func runCommand(args []string) error {
+	err := command(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil // usage was already printed
+	}
+	return err
+}
+
+func command(args []string) error {
+	switch args[0] {
+	case "cluster":
+		return runCluster(args[1:])
+	case "task":
+		return runTask(args[1:])
+	case "exec":
+		return runExec(args[1:])
+	case "chat":
+		return runChat(args[1:])
+	case "serve":
+		return runServe(args[1:])
+	case "db":
+		return runDB(args[1:])
+	case "fake-claude":
+		return runFakeClaude(args[1:])
+	case "help", "-h", "-help", "--help":
+		fmt.Println(usage)
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, usage)
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+// dispatch runs the subcommand of group named by args[0].
+func dispatch(group string, args []string, cmds map[string]func([]string) error, help string) error {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, help)
+		return fmt.Errorf("%s: missing subcommand", group)
+	}
+	cmd, ok := cmds[args[0]]
+	if !ok {
+		fmt.Fprintln(os.Stderr, help)
+		return fmt.Errorf("%s: unknown subcommand %q", group, args[0])
+	}
+	return cmd(args[1:])
+}
+
+// cmdFlags is the flag set of one subcommand plus the flags most of them
+// share.
+type cmdFlags struct {
+	*flag.FlagSet
+	repo *string
+	json *bool
+}
+
+// newFlags returns a flag set for the named subcommand. withRepo adds
+// --repo for commands that operate on a cluster.
+func newFlags(name, help string, withRepo bool) *cmdFlags {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), help)
+		fs.PrintDefaults()
+	}
+	f := &cmdFlags{FlagSet: fs}
+	if withRepo {
+		f.repo = fs.String("repo", ".", "path to the cluster's repository")
+	}
+	f.json = fs.Bool("json", false, "print JSON")
+	return f
+}
+
+// parse parses args, allowing flags after positional arguments, and returns
+// the positional arguments. It fails unless there are between min and max
+// of them; max < 0 means no limit.
+func (f *cmdFlags) parse(args []string, min, max int) ([]string, error) {
+	var pos []string
+	for {
+		if err := f.Parse(args); err != nil {
+			return nil, err
+		}
+		args = f.Args()
+		if len(args) == 0 {
+			break
+		}
+		pos = append(pos, args[0])
+		args = args[1:]
+	}
+	if len(pos) < min || (max >= 0 && len(pos) > max) {
+		f.Usage()
+		return nil, fmt.Errorf("%s: wrong number of arguments", f.Name())
+	}
+	return pos, nil
+}
+
+// openApp opens the cluster at repoPath. The caller must Close the App.
+func openApp(ctx context.Context, repoPath string) (*app.App, error) {
+	a := app.New()
+	if err := a.OpenCluster(ctx, repoPath); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// parseID parses a positional task or execution ID.
+func parseID(kind, s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid %s id %q", kind, s)
+	}
+	return id, nil
+}
+
+// readText returns s, or all of stdin when s is "-".
+func readText(s string) (string, error) {
+	if s != "-" {
+		return s, nil
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("read stdin: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// oneLine collapses s to a single line of at most n runes for tables.
+func oneLine(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"bore-tui/internal/app"
+	"bore-tui/internal/db"
+)
+
+const clusterUsage = `usage: bore-tui cluster init|open|list
+
+  init [path] [--json]   create .bore/ in a git repository and register it
+  open [path] [--json]   open a cluster, recovering interrupted executions
+  list [--json]          list known clusters, most recently opened first`
+
+// clusterJSON is the JSON form of a cluster.
+type clusterJSON struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	RepoPath  string    `json:"repo_path"`
+	RemoteURL *string   `json:"remote_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newClusterJSON(c *db.Cluster) clusterJSON {
+	return clusterJSON{ID: c.ID, Name: c.Name, RepoPath: c.RepoPath, RemoteURL: c.RemoteURL, CreatedAt: c.CreatedAt}
+}
+
+// runCluster implements the "cluster" subcommands.
+func runCluster(args []string) error {
+	return dispatch("cluster", args, map[string]func([]string) error{
+		"init": func(args []string) error { return clusterInitOrOpen("init", args) },
+		"open": func(args []string) error { return clusterInitOrOpen("open", args) },
+		"list": clusterList,
+	}, clusterUsage)
+}
+
+// clusterInitOrOpen implements "cluster init" and "cluster open"; both end
+// with the cluster open and recorded as the last used one.
+func clusterInitOrOpen(op string, args []string) error {
+	f := newFlags("cluster "+op, clusterUsage, false)
+	pos, err := f.parse(args, 0, 1)
+	if err != nil {
+		return err
+	}
+	path := "."
+	if len(pos) > 0 {
+		path = pos[0]
+	}
+
+	ctx := context.Background()
+	a := app.New()
+	defer a.Close()
+	if op == "init" {
+		err = a.InitCluster(ctx, path)
+	} else {
+		err = a.OpenCluster(ctx, path)
+	}
+	if err != nil {
+		return fmt.Errorf("cluster %s: %w", op, err)
+	}
+
+	c := a.Cluster()
+	if *f.json {
+		return printJSON(newClusterJSON(c))
+	}
+	verb := "Opened"
+	if op == "init" {
+		verb = "Initialized"
+	}
+	fmt.Printf("%s cluster %s (id %d) at %s\n", verb, c.Name, c.ID, c.RepoPath)
+	return nil
+}
+
+// clusterList implements "cluster list".
+func clusterList(args []string) error {
+	f := newFlags("cluster list", clusterUsage, false)
+	if _, err := f.parse(args, 0, 0); err != nil {
+		return err
+	}
+
+	type item struct {
+		Path string `json:"path"`
+		Name string `json:"name"`
+		Last bool   `json:"last"`
+	}
+	a := app.New()
+	last := a.LastCluster()
+	items := []item{}
+	for _, p := range a.KnownClusters() {
+		items = append(items, item{Path: p, Name: filepath.Base(p), Last: p == last})
+	}
+
+	if *f.json {
+		return printJSON(items)
+	}
+	if len(items) == 0 {
+		fmt.Println("No known clusters. Create one with: bore-tui cluster init <path>")
+		return nil
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tPATH\t")
+	for _, it := range items {
+		mark := ""
+		if it.Last {
+			mark = "(last)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", it.Name, it.Path, mark)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"bore-tui/internal/db"
+)
+
+const dbUsage = `usage: bore-tui db migrate [--status] [--json] [repo-path]
+
+Applies pending schema migrations to the cluster database in
+<repo-path>/.bore/bore.db (default: the current directory), backing the
+database up first. With --status, lists migrations without applying them.`
+
+// runDB implements the "db" subcommand.
+func runDB(args []string) error {
+	if len(args) == 0 || args[0] != "migrate" {
+		fmt.Fprintln(os.Stderr, dbUsage)
+		return fmt.Errorf("db: unknown or missing subcommand")
+	}
+
+	f := newFlags("db migrate", dbUsage, false)
+	status := f.Bool("status", false, "list migrations without applying them")
+	pos, err := f.parse(args[1:], 0, 1)
+	if err != nil {
+		return err
+	}
+
+	repoPath := "."
+	if len(pos) > 0 {
+		repoPath = pos[0]
+	}
+	absPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return fmt.Errorf("db: resolve repo path: %w", err)
+	}
+	dbPath := filepath.Join(absPath, ".bore", "bore.db")
+	if _, err := os.Stat(dbPath); err != nil {
+		return fmt.Errorf("db: no cluster database at %s: %w", dbPath, err)
+	}
+
+	d, err := db.OpenUnmigrated(dbPath)
+	if err != nil {
+		return fmt.Errorf("db: %w", err)
+	}
+	defer d.Close()
+
+	ctx := context.Background()
+	if *status {
+		return printMigrationStatus(ctx, d, *f.json)
+	}
+
+	applied, backup, err := d.Migrate(ctx)
+	if *f.json {
+		if applied == nil {
+			applied = []db.Migration{}
+		}
+		if jerr := printJSON(map[string]any{"applied": applied, "backup": backup}); jerr != nil && err == nil {
+			err = jerr
+		}
+		if err != nil {
+			return fmt.Errorf("db: migrate: %w", err)
+		}
+		return nil
+	}
+	if backup != "" {
+		fmt.Printf("Backed up database to %s\n", backup)
+	}
+	for _, m := range applied {
+		fmt.Printf("Applied %04d %s\n", m.Version, m.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("db: migrate: %w", err)
+	}
+	if len(applied) == 0 {
+		fmt.Println("Database is up to date.")
+	}
+	return nil
+}
+
+// printMigrationStatus writes the migrations and their state as a table or
+// as JSON.
+func printMigrationStatus(ctx context.Context, d *db.DB, asJSON bool) error {
+	migrations, err := d.MigrationStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("db: migration status: %w", err)
+	}
+	if asJSON {
+		return printJSON(migrations)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, m := range migrations {
+		state, at := "pending", "-"
+		if m.AppliedAt != nil {
+			state = "applied"
+			at = m.AppliedAt.Local().Format("2006-01-02 15:04:05")
+		}
+		if m.Unknown {
+			state = "unknown (newer bore-tui)"
+		}
+		fmt.Fprintf(tw, "%04d\t%s\t%s\t%s\n", m.Version, m.Name, state, at)
+	}
+	return tw.Flush()
+}
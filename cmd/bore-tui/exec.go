@@ -0,0 +1,469 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"bore-tui/internal/app"
+	"bore-tui/internal/db"
+	"bore-tui/internal/engine"
+)
+
+const execUsage = `usage: bore-tui exec start|resume|status|logs|diff|merge|revert
+
+  start <task-id> [--base BRANCH] [-v]
+      create an execution for a task and run it in the foreground
+  resume <exec-id> [-v]
+      resume an interrupted or cancelled execution in the foreground
+  status <exec-id>    show an execution, its agent runs and questions
+  logs <exec-id>      print the execution's event log
+  diff <exec-id>      print git status and diff of the execution's worktree
+  merge <exec-id>     commit, merge into the base branch and clean up
+  revert <exec-id>    discard all changes in the execution's worktree
+
+All subcommands take --repo and --json. With --json, start and resume
+print one engine event per line. Interrupting start or resume cancels the
+execution; it can be resumed later. Questions the execution raises are
+asked on the terminal. When stdin is not one, or with --json, worker
+blockers are accepted as is and any other question cancels the execution.`
+
+// runExec implements the "exec" subcommands.
+func runExec(args []string) error {
+	return dispatch("exec", args, map[string]func([]string) error{
+		"start":  execStart,
+		"resume": execResume,
+		"status": execStatus,
+		"logs":   execLogs,
+		"diff":   execDiff,
+		"merge":  execMerge,
+		"revert": execRevert,
+	}, execUsage)
+}
+
+// execStart implements "exec start".
+func execStart(args []string) error {
+	f := newFlags("exec start", execUsage, true)
+	base := f.String("base", "main", "branch to create the execution branch from")
+	verbose := f.Bool("v", false, "also print raw agent output")
+	pos, err := f.parse(args, 1, 1)
+	if err != nil {
+		return err
+	}
+	taskID, err := parseID("task", pos[0])
+	if err != nil {
+		return fmt.Errorf("exec start: %w", err)
+	}
+
+	ctx := context.Background()
+	a, err := openApp(ctx, *f.repo)
+	if err != nil {
+		return fmt.Errorf("exec start: %w", err)
+	}
+	defer a.Close()
+
+	task, err := a.DB().GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("exec start: %w", err)
+	}
+	if task.Status == db.StatusRunning || task.Status == db.StatusAwaitingUser {
+		return fmt.Errorf("exec start: task %d is already running", task.ID)
+	}
+
+	eng := engine.New(a)
+	exec, err := eng.Prepare(ctx, task, *base, nil)
+	if err != nil {
+		return fmt.Errorf("exec start: %w", err)
+	}
+	return follow(a, eng, exec.ID, *f.json, *verbose, func() error { return eng.Start(exec.ID) })
+}
+
+// execResume implements "exec resume".
+func execResume(args []string) error {
+	f := newFlags("exec resume", execUsage, true)
+	verbose := f.Bool("v", false, "also print raw agent output")
+	pos, err := f.parse(args, 1, 1)
+	if err != nil {
+		return err
+	}
+	execID, err := parseID("execution", pos[0])
+	if err != nil {
+		return fmt.Errorf("exec resume: %w", err)
+	}
+
+	a, err := openApp(context.Background(), *f.repo)
+	if err != nil {
+		return fmt.Errorf("exec resume: %w", err)
+	}
+	defer a.Close()
+
+	eng := engine.New(a)
+	return follow(a, eng, execID, *f.json, *verbose, func() error { return eng.Resume(execID) })
+}
+
+// follow starts an execution with start and prints its events until it
+// finishes. SIGINT and SIGTERM cancel the execution. It returns an error
+// unless the execution finished ready for review.
+func follow(a *app.App, eng *engine.Engine, execID int64, asJSON, verbose bool, start func() error) error {
+	events, unsubscribe := eng.Subscribe()
+	defer unsubscribe()
+	if err := start(); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		_ = eng.Wait(context.Background(), execID)
+		close(done)
+	}()
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	// Answers are read from the terminal in the background so that signals,
+	// events and the end of the run are handled while a prompt is up.
+	var lines chan string
+	if fi, err := os.Stdin.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 && !asJSON {
+		lines = make(chan string)
+		go readLines(os.Stdin, lines)
+	}
+	var asking []engine.Event // open questions, the first one prompted
+
+	for running := true; running; {
+		select {
+		case ev := <-events:
+			if ev.ExecutionID != execID {
+				continue
+			}
+			printEvent(ev, asJSON, verbose)
+			switch {
+			case ev.Kind == engine.EventQuestion && lines == nil:
+				answerUnattended(eng, execID, ev)
+			case ev.Kind == engine.EventQuestion:
+				asking = append(asking, ev)
+				if len(asking) == 1 {
+					promptQuestion(ev)
+				}
+			case ev.Kind == engine.EventAnswered:
+				// Answered elsewhere, e.g. in the web GUI.
+				for i, q := range asking {
+					if q.QuestionID == ev.QuestionID {
+						asking = slices.Delete(asking, i, i+1)
+						if i == 0 && len(asking) > 0 {
+							promptQuestion(asking[0])
+						}
+						break
+					}
+				}
+			}
+		case line, ok := <-lines:
+			if !ok {
+				lines = nil
+				continue
+			}
+			if len(asking) == 0 {
+				continue
+			}
+			q := asking[0]
+			asking = asking[1:]
+			if err := eng.Answer(q.QuestionID, strings.TrimSpace(line)); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+			}
+			if len(asking) > 0 {
+				promptQuestion(asking[0])
+			}
+		case <-sigs:
+			if !asJSON {
+				fmt.Fprintln(os.Stderr, "Cancelling execution...")
+			}
+			_ = eng.Cancel(execID)
+		case <-done:
+			running = false
+		}
+	}
+	if len(asking) > 0 {
+		fmt.Fprintln(os.Stderr) // end the prompt left unanswered
+	}
+	// Print whatever was published before the run ended.
+	for drained := false; !drained; {
+		select {
+		case ev := <-events:
+			if ev.ExecutionID == execID {
+				printEvent(ev, asJSON, verbose)
+			}
+		default:
+			drained = true
+		}
+	}
+
+	exec, err := a.DB().GetExecution(context.Background(), execID)
+	if err != nil {
+		return err
+	}
+	if !asJSON {
+		fmt.Printf("Execution %d finished: %s\n", execID, exec.Status)
+	}
+	if exec.Status != db.StatusDiffReview && exec.Status != db.StatusCompleted {
+		return fmt.Errorf("execution %d ended %s", execID, exec.Status)
+	}
+	return nil
+}
+
+// printEvent writes one engine event, as a JSON line or as text.
+func printEvent(ev engine.Event, asJSON, verbose bool) {
+	if ev.Kind == engine.EventAgentOutput && !verbose {
+		return
+	}
+	if asJSON {
+		data, _ := json.Marshal(ev)
+		fmt.Println(string(data))
+		return
+	}
+	switch ev.Kind {
+	case engine.EventStep:
+		fmt.Printf("==> %s\n", ev.Step)
+	case engine.EventOutput:
+		fmt.Println(ev.Message)
+	case engine.EventAgentOutput:
+		fmt.Printf("[%s] %s\n", ev.Agent, ev.Message)
+	}
+}
+
+// readLines sends each line read from r to lines and closes it at EOF.
+func readLines(r io.Reader, lines chan<- string) {
+	defer close(lines)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		lines <- sc.Text()
+	}
+}
+
+// promptQuestion asks for the answer to a question on the terminal.
+func promptQuestion(ev engine.Event) {
+	switch ev.QuestionKind {
+	case db.QuestionOwnership:
+		fmt.Fprintf(os.Stderr, "Answer question #%d (\"keep\" keeps the changes, anything else reverts them): ", ev.QuestionID)
+	case db.QuestionBudget:
+		fmt.Fprintf(os.Stderr, "Answer question #%d (\"continue\" carries on past the budget, anything else stops): ", ev.QuestionID)
+	default:
+		fmt.Fprintf(os.Stderr, "Answer question #%d (empty to accept the worker's result): ", ev.QuestionID)
+	}
+}
+
+// answerUnattended handles a question when no one can answer it: a worker
+// blocker is accepted as is, while any other question would decide
+// something on the user's behalf, so the execution is cancelled instead.
+// It can be resumed later.
+func answerUnattended(eng *engine.Engine, execID int64, ev engine.Event) {
+	if ev.QuestionKind != db.QuestionBlocker {
+		fmt.Fprintf(os.Stderr, "No terminal to answer %s question #%d: cancelling execution %d\n", ev.QuestionKind, ev.QuestionID, execID)
+		_ = eng.Cancel(execID)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "No terminal: accepting the worker's result for question #%d\n", ev.QuestionID)
+	if err := eng.Answer(ev.QuestionID, ""); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+}
+
+// openExecution parses the execution ID in args, opens the cluster and loads
+// the execution. The caller must Close the App.
+func openExecution(name string, args []string) (*cmdFlags, *app.App, *db.Execution, error) {
+	f := newFlags(name, execUsage, true)
+	pos, err := f.parse(args, 1, 1)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	execID, err := parseID("execution", pos[0])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%s: %w", name, err)
+	}
+	ctx := context.Background()
+	a, err := openApp(ctx, *f.repo)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%s: %w", name, err)
+	}
+	exec, err := a.DB().GetExecution(ctx, execID)
+	if err != nil {
+		a.Close()
+		return nil, nil, nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return f, a, exec, nil
+}
+
+// execStatus implements "exec status".
+func execStatus(args []string) error {
+	f, a, exec, err := openExecution("exec status", args)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	ctx := context.Background()
+	task, err := a.DB().GetTask(ctx, exec.TaskID)
+	if err != nil {
+		return fmt.Errorf("exec status: %w", err)
+	}
+	runs, err := a.DB().GetAgentRuns(ctx, exec.ID)
+	if err != nil {
+		return fmt.Errorf("exec status: %w", err)
+	}
+	questions, err := a.DB().ListQuestions(ctx, exec.ID)
+	if err != nil {
+		return fmt.Errorf("exec status: %w", err)
+	}
+
+	if *f.json {
+		if runs == nil {
+			runs = []db.AgentRun{}
+		}
+		if questions == nil {
+			questions = []db.ExecutionQuestion{}
+		}
+		return printJSON(struct {
+			*db.Execution
+			TaskTitle string                 `json:"task_title"`
+			AgentRuns []db.AgentRun          `json:"agent_runs"`
+			Questions []db.ExecutionQuestion `json:"questions"`
+		}{exec, task.Title, runs, questions})
+	}
+
+	fmt.Printf("Execution %d: %s\n", exec.ID, task.Title)
+	fmt.Printf("Status:   %s\n", exec.Status)
+	fmt.Printf("Branch:   %s (from %s)\n", exec.ExecBranch, exec.BaseBranch)
+	fmt.Printf("Worktree: %s\n", exec.WorktreePath)
+	if exec.StartedAt != nil {
+		fmt.Printf("Started:  %s\n", exec.StartedAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	if exec.FinishedAt != nil {
+		fmt.Printf("Finished: %s\n", exec.FinishedAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	if len(runs) > 0 {
+		fmt.Println()
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "RUN\tTYPE\tROLE\tOUTCOME\tTOKENS\tCOST\tSUMMARY")
+		var total db.Usage
+		for _, ar := range runs {
+			total.Add(ar.Usage)
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%d\t$%.4f\t%s\n", ar.ID, ar.AgentType, ar.Role, ar.Outcome,
+				ar.Tokens(), ar.CostUSD, oneLine(ar.Summary, 60))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		fmt.Printf("\nUsage:    %d tokens, $%.4f, %s agent time\n",
+			total.Tokens(), total.CostUSD, (time.Duration(total.DurationMS) * time.Millisecond).Round(time.Second))
+	}
+	for _, q := range questions {
+		if q.Status == db.QuestionOpen {
+			fmt.Printf("\nOpen question #%d from %s:\n%s\n", q.ID, q.AgentRole, q.Question)
+		}
+	}
+	return nil
+}
+
+// execLogs implements "exec logs".
+func execLogs(args []string) error {
+	f, a, exec, err := openExecution("exec logs", args)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	events, err := a.DB().ListEvents(context.Background(), exec.ID)
+	if err != nil {
+		return fmt.Errorf("exec logs: %w", err)
+	}
+	if *f.json {
+		if events == nil {
+			events = []db.ExecutionEvent{}
+		}
+		return printJSON(events)
+	}
+	for _, ev := range events {
+		fmt.Printf("%s %-5s %-18s %s\n", ev.Ts.Local().Format("2006-01-02 15:04:05"),
+			strings.ToUpper(ev.Level), ev.EventType, ev.Message)
+	}
+	return nil
+}
+
+// execDiff implements "exec diff".
+func execDiff(args []string) error {
+	f, a, exec, err := openExecution("exec diff", args)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	ctx := context.Background()
+	status, err := a.Repo().Status(ctx, exec.WorktreePath)
+	if err != nil {
+		return fmt.Errorf("exec diff: git status: %w", err)
+	}
+	diff, err := a.Repo().DiffAll(ctx, exec.WorktreePath)
+	if err != nil {
+		return fmt.Errorf("exec diff: git diff: %w", err)
+	}
+
+	if *f.json {
+		return printJSON(map[string]string{"status": status, "diff": diff})
+	}
+	if strings.TrimSpace(status) == "" && strings.TrimSpace(diff) == "" {
+		fmt.Println("No changes.")
+		return nil
+	}
+	fmt.Print(status)
+	if !strings.HasSuffix(status, "\n") {
+		fmt.Println()
+	}
+	fmt.Println()
+	fmt.Print(diff)
+	return nil
+}
+
+// execMerge implements "exec merge".
+func execMerge(args []string) error {
+	f, a, exec, err := openExecution("exec merge", args)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	baseBranch, err := engine.New(a).Merge(context.Background(), exec.ID)
+	if err != nil {
+		return fmt.Errorf("exec merge: %w", err)
+	}
+	if *f.json {
+		return printJSON(map[string]any{"ok": true, "branch": exec.ExecBranch, "base_branch": baseBranch})
+	}
+	fmt.Printf("Merged %s into %s. Worktree and branch cleaned up.\n", exec.ExecBranch, baseBranch)
+	return nil
+}
+
+// execRevert implements "exec revert".
+func execRevert(args []string) error {
+	f, a, exec, err := openExecution("exec revert", args)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	if err := engine.New(a).Revert(context.Background(), exec.ID); err != nil {
+		return fmt.Errorf("exec revert: %w", err)
+	}
+	if *f.json {
+		return printJSON(map[string]bool{"ok": true})
+	}
+	fmt.Printf("Reverted all changes in %s.\n", exec.WorktreePath)
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"bore-tui/internal/process"
+)
+
+// replayDirEnv names the recording directory fake-claude replays from.
+const replayDirEnv = "BORE_REPLAY_DIR"
+
+const fakeClaudeUsage = `usage: bore-tui fake-claude [claude flags]
+
+Stands in for the claude executable by replaying runs recorded with
+agents.record_runs. The prompt is read from stdin and looked up by hash in
+the directory named by $BORE_REPLAY_DIR; the recorded file changes are
+applied to the current directory and the recorded response is printed, as
+stream-json if --output-format stream-json is given. Other claude flags
+are accepted and ignored.
+
+To use it, point agents.claude_cli_path at a script such as
+
+  #!/bin/sh
+  BORE_REPLAY_DIR=/path/to/repo/.bore/runs/20260101-120000 \
+    exec bore-tui fake-claude "$@"
+
+Every invocation is a new process, so a prompt recorded more than once is
+always answered with its first recording. A "replay" backend replays
+repeats in order.`
+
+// runFakeClaude implements the "fake-claude" subcommand.
+func runFakeClaude(args []string) error {
+	stream := false
+	for i, a := range args {
+		switch {
+		case a == "-h" || a == "--help":
+			fmt.Println(fakeClaudeUsage)
+			return nil
+		case a == "--output-format=stream-json",
+			a == "--output-format" && i+1 < len(args) && args[i+1] == "stream-json":
+			stream = true
+		}
+	}
+
+	dir := os.Getenv(replayDirEnv)
+	if dir == "" {
+		fmt.Fprintln(os.Stderr, fakeClaudeUsage)
+		return fmt.Errorf("fake-claude: %s is not set", replayDirEnv)
+	}
+	prompt, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("fake-claude: read prompt: %w", err)
+	}
+	replayer, err := process.NewReplayer(dir)
+	if err != nil {
+		return fmt.Errorf("fake-claude: %w", err)
+	}
+	rec, err := replayer.Next(string(prompt))
+	if err != nil {
+		return fmt.Errorf("fake-claude: %w", err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("fake-claude: %w", err)
+	}
+	if err := process.ApplyFileChanges(wd, rec.Files); err != nil {
+		return fmt.Errorf("fake-claude: %w", err)
+	}
+
+	if stream {
+		for _, line := range rec.StreamJSON() {
+			fmt.Println(line)
+		}
+	} else if rec.Text != "" {
+		fmt.Print(rec.Text)
+		if !strings.HasSuffix(rec.Text, "\n") {
+			fmt.Println()
+		}
+	}
+	if rec.Err != "" {
+		return fmt.Errorf("fake-claude: recorded run failed: %s", rec.Err)
+	}
+	return nil
+}
@@ -1,17 +1,29 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"bore-tui/internal/app"
+	"bore-tui/internal/engine"
 	"bore-tui/internal/tui"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// shutdownTimeout bounds how long exit waits for running executions to stop.
+const shutdownTimeout = 10 * time.Second
+
 func main() {
-	if err := run(); err != nil {
+	var err error
+	if len(os.Args) > 1 {
+		err = runCommand(os.Args[1:])
+	} else {
+		err = run()
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
@@ -21,12 +33,24 @@ func run() error {
 	a := app.New()
 	defer a.Close()
 
-	model := tui.NewModel(a)
+	eng := engine.New(a)
+	model := tui.NewModel(a, eng)
 
 	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 
-	if _, err := p.Run(); err != nil {
-		return fmt.Errorf("bore-tui: %w", err)
+	_, runErr := p.Run()
+	model.Close()
+
+	// Stop in-flight executions so no Claude CLI processes outlive the TUI
+	// and their state is recorded as interrupted.
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := eng.Shutdown(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+
+	if runErr != nil {
+		return fmt.Errorf("bore-tui: %w", runErr)
 	}
 
 	return nil
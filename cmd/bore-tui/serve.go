@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"bore-tui/internal/engine"
+	"bore-tui/internal/web"
+)
+
+const serveUsage = `usage: bore-tui serve [--repo PATH] [--addr HOST:PORT] [--expose] [--no-browser] [--json]
+
+Runs the web GUI for a cluster without the terminal UI, until SIGINT or
+SIGTERM. On shutdown the server stops accepting requests and running
+executions are interrupted so they can be resumed later. SIGHUP is
+ignored, so serve survives the terminal that started it closing.
+
+The server listens on loopback only unless --expose is given. Every API
+request needs the access token generated at startup; open the printed URL,
+which carries it, or send it as "Authorization: Bearer <token>". The
+token travels in clear text, so only expose the server on a trusted
+network or behind a TLS proxy.`
+
+// runServe implements the "serve" subcommand.
+func runServe(args []string) error {
+	f := newFlags("serve", serveUsage, true)
+	addr := f.String("addr", "127.0.0.1:8742", "address to listen on")
+	expose := f.Bool("expose", false, "allow listening on non-loopback addresses")
+	noBrowser := f.Bool("no-browser", false, "do not open the GUI in a browser")
+	if _, err := f.parse(args, 0, 0); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	a, err := openApp(ctx, *f.repo)
+	if err != nil {
+		return fmt.Errorf("serve: %w", err)
+	}
+	defer a.Close()
+
+	eng := engine.New(a)
+	srv := web.New(a, eng)
+	url, err := srv.StartWithOptions(ctx, web.StartOptions{Addr: *addr, Expose: *expose, OpenBrowser: !*noBrowser})
+	if err != nil {
+		return fmt.Errorf("serve: %w", err)
+	}
+
+	signal.Ignore(syscall.SIGHUP)
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	if *f.json {
+		if err := printJSON(map[string]any{
+			"url":     url,
+			"token":   srv.Token(),
+			"cluster": a.Cluster().Name,
+			"pid":     os.Getpid(),
+		}); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("Serving cluster %s at %s\n", a.Cluster().Name, url)
+	}
+
+	var serveErr error
+	select {
+	case sig := <-sigs:
+		fmt.Fprintf(os.Stderr, "Received %s, shutting down...\n", sig)
+	case serveErr = <-srv.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Stop(shutdownCtx); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+	if err := eng.Shutdown(shutdownCtx); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+	return serveErr
+}
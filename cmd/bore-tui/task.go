@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"bore-tui/internal/db"
+)
+
+const taskUsage = `usage: bore-tui task new|list|show
+
+  new --title T --prompt P --thread NAME [--complexity C] [--mode M]
+      create a pending task. --prompt - reads the prompt from stdin. The
+      thread is looked up by name or ID and created if no thread has that
+      name.
+  list [--status S]   list tasks, optionally only those with status S
+  show <task-id>      show a task and its executions
+
+All subcommands take --repo and --json.`
+
+// runTask implements the "task" subcommands.
+func runTask(args []string) error {
+	return dispatch("task", args, map[string]func([]string) error{
+		"new":  taskNew,
+		"list": taskList,
+		"show": taskShow,
+	}, taskUsage)
+}
+
+// taskNew implements "task new".
+func taskNew(args []string) error {
+	f := newFlags("task new", taskUsage, true)
+	title := f.String("title", "", "task title (required)")
+	prompt := f.String("prompt", "", "task prompt, or - to read it from stdin (required)")
+	threadArg := f.String("thread", "", "thread name or ID (required)")
+	complexity := f.String("complexity", db.ComplexityBasic, "basic, medium or complex")
+	mode := f.String("mode", db.ModeJustGetItDone, "just_get_it_done or alert_with_issues")
+	if _, err := f.parse(args, 0, 0); err != nil {
+		return err
+	}
+	text, err := readText(*prompt)
+	if err != nil {
+		return fmt.Errorf("task new: %w", err)
+	}
+	if *title == "" || text == "" || *threadArg == "" {
+		f.Usage()
+		return fmt.Errorf("task new: --title, --prompt and --thread are required")
+	}
+
+	ctx := context.Background()
+	a, err := openApp(ctx, *f.repo)
+	if err != nil {
+		return fmt.Errorf("task new: %w", err)
+	}
+	defer a.Close()
+
+	thread, err := findOrCreateThread(ctx, a.DB(), a.Cluster().ID, *threadArg)
+	if err != nil {
+		return fmt.Errorf("task new: %w", err)
+	}
+	task, err := a.DB().CreateTask(ctx, a.Cluster().ID, thread.ID, *title, text, *complexity, *mode)
+	if err != nil {
+		return fmt.Errorf("task new: %w", err)
+	}
+
+	if *f.json {
+		return printJSON(task)
+	}
+	fmt.Printf("Created task %d in thread %s: %s\n", task.ID, thread.Name, task.Title)
+	return nil
+}
+
+// findOrCreateThread resolves a thread by ID or name, creating a thread
+// with that name if none exists.
+func findOrCreateThread(ctx context.Context, d *db.DB, clusterID int64, nameOrID string) (*db.Thread, error) {
+	threads, err := d.ListThreads(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	id, idErr := strconv.ParseInt(nameOrID, 10, 64)
+	for i := range threads {
+		if (idErr == nil && threads[i].ID == id) || strings.EqualFold(threads[i].Name, nameOrID) {
+			return &threads[i], nil
+		}
+	}
+	return d.CreateThread(ctx, clusterID, nameOrID, "")
+}
+
+// taskList implements "task list".
+func taskList(args []string) error {
+	f := newFlags("task list", taskUsage, true)
+	status := f.String("status", "", "only list tasks with this status")
+	if _, err := f.parse(args, 0, 0); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	a, err := openApp(ctx, *f.repo)
+	if err != nil {
+		return fmt.Errorf("task list: %w", err)
+	}
+	defer a.Close()
+
+	var tasks []db.Task
+	if *status != "" {
+		tasks, err = a.DB().ListTasksByStatus(ctx, a.Cluster().ID, *status)
+	} else {
+		tasks, err = a.DB().ListTasks(ctx, a.Cluster().ID)
+	}
+	if err != nil {
+		return fmt.Errorf("task list: %w", err)
+	}
+
+	if *f.json {
+		if tasks == nil {
+			tasks = []db.Task{}
+		}
+		return printJSON(tasks)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTATUS\tCOMPLEXITY\tMODE\tTITLE")
+	for _, t := range tasks {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", t.ID, t.Status, t.Complexity, t.Mode, oneLine(t.Title, 60))
+	}
+	return tw.Flush()
+}
+
+// taskShow implements "task show".
+func taskShow(args []string) error {
+	f := newFlags("task show", taskUsage, true)
+	pos, err := f.parse(args, 1, 1)
+	if err != nil {
+		return err
+	}
+	id, err := parseID("task", pos[0])
+	if err != nil {
+		return fmt.Errorf("task show: %w", err)
+	}
+
+	ctx := context.Background()
+	a, err := openApp(ctx, *f.repo)
+	if err != nil {
+		return fmt.Errorf("task show: %w", err)
+	}
+	defer a.Close()
+
+	task, err := a.DB().GetTask(ctx, id)
+	if err != nil {
+		return fmt.Errorf("task show: %w", err)
+	}
+	thread, err := a.DB().GetThread(ctx, task.ThreadID)
+	if err != nil {
+		return fmt.Errorf("task show: %w", err)
+	}
+	all, err := a.DB().ListExecutions(ctx, a.Cluster().ID)
+	if err != nil {
+		return fmt.Errorf("task show: %w", err)
+	}
+	execs := []db.Execution{}
+	for _, ex := range all {
+		if ex.TaskID == task.ID {
+			execs = append(execs, ex)
+		}
+	}
+
+	if *f.json {
+		return printJSON(struct {
+			*db.Task
+			Thread     string         `json:"thread"`
+			Executions []db.Execution `json:"executions"`
+		}{task, thread.Name, execs})
+	}
+	fmt.Printf("Task %d: %s\n", task.ID, task.Title)
+	fmt.Printf("Status:     %s\n", task.Status)
+	fmt.Printf("Thread:     %s\n", thread.Name)
+	fmt.Printf("Complexity: %s\n", task.Complexity)
+	fmt.Printf("Mode:       %s\n", task.Mode)
+	fmt.Printf("Created:    %s\n\n", task.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Println(task.Prompt)
+	if len(execs) == 0 {
+		return nil
+	}
+	fmt.Println()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "EXECUTION\tSTATUS\tBRANCH\tCREATED")
+	for _, ex := range execs {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", ex.ID, ex.Status, ex.ExecBranch, ex.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	return tw.Flush()
+}
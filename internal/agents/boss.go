@@ -13,6 +13,7 @@ type BossContext struct {
 	TaskPrompt   string
 	Mode         string // "just_get_it_done" or "alert_with_issues"
 	WorkerBudget int
+	UserGuidance []string // user answers to blockers escalated during execution
 }
 
 // BuildBossSystemPrompt returns the Boss's system prompt with injected context.
@@ -116,51 +117,63 @@ Respond with ONLY the following JSON (no markdown fences, no extra text):
 	return b.String()
 }
 
+// BuildBossReviewPrompt returns the user message asking Boss to review the
+// results collected so far and either request more workers or finish.
+// remainingBudget is how many more workers Boss may spawn.
+func BuildBossReviewPrompt(workerResults []WorkerResult, remainingBudget int) string {
+	var b strings.Builder
+
+	writeWorkerResults(&b, workerResults)
+
+	b.WriteString("## Instructions\n\n")
+	fmt.Fprintf(&b, "You may spawn at most %d more workers for this execution.\n\n", remainingBudget)
+	b.WriteString(`Review all worker results above against the plan and success criteria.
+
+If the work is incomplete, a worker failed, or validation is still needed, request additional workers with narrow roles. Do not re-spawn work that already succeeded.
+
+Respond with ONLY the following JSON (no markdown fences, no extra text):
+
+{
+  "type": "spawn_workers",
+  "workers": [
+    {
+      "role": "Worker role name",
+      "goal": "Specific goal for this worker",
+      "files_or_paths": ["target/files/or/dirs"],
+      "commands": ["allowed commands to run"],
+      "success_criteria": ["How to know this worker succeeded"]
+    }
+  ]
+}
+
+If no more workers are needed, respond instead with your final summary in the Boss Summary format:
+
+{
+  "type": "boss_summary",
+  "outcome": "success | partial | failed",
+  "what_changed": ["Summary of each meaningful change"],
+  "files_touched": ["all/files/modified.go"],
+  "commands_run": ["all commands that were run"],
+  "validation_results": ["Result of each validation step"],
+  "risks_or_followups": ["Any remaining risks or follow-up tasks"],
+  "lessons": [
+    {
+      "lesson_type": "error | pattern | warning | note",
+      "content": "What was learned"
+    }
+  ]
+}
+`)
+
+	return b.String()
+}
+
 // BuildBossSummaryPrompt returns the user message asking Boss for a final summary.
 // workerResults are the collected worker outputs from this execution.
 func BuildBossSummaryPrompt(workerResults []WorkerResult) string {
 	var b strings.Builder
 
-	b.WriteString("## Worker Results\n\n")
-
-	if len(workerResults) == 0 {
-		b.WriteString("No worker results collected.\n\n")
-	} else {
-		for i, wr := range workerResults {
-			fmt.Fprintf(&b, "### Worker %d - %s\n\n", i+1, wr.Outcome)
-			if wr.Summary != "" {
-				fmt.Fprintf(&b, "%s\n\n", wr.Summary)
-			}
-			if len(wr.FilesChanged) > 0 {
-				b.WriteString("**Files changed**:\n")
-				for _, f := range wr.FilesChanged {
-					fmt.Fprintf(&b, "- %s\n", f)
-				}
-				b.WriteByte('\n')
-			}
-			if len(wr.CommandsRun) > 0 {
-				b.WriteString("**Commands run**:\n")
-				for _, c := range wr.CommandsRun {
-					fmt.Fprintf(&b, "- `%s`\n", c)
-				}
-				b.WriteByte('\n')
-			}
-			if len(wr.ValidationResults) > 0 {
-				b.WriteString("**Validation results**:\n")
-				for _, v := range wr.ValidationResults {
-					fmt.Fprintf(&b, "- %s\n", v)
-				}
-				b.WriteByte('\n')
-			}
-			if len(wr.Blockers) > 0 {
-				b.WriteString("**Blockers**:\n")
-				for _, bl := range wr.Blockers {
-					fmt.Fprintf(&b, "- %s\n", bl)
-				}
-				b.WriteByte('\n')
-			}
-		}
-	}
+	writeWorkerResults(&b, workerResults)
 
 	b.WriteString(`## Instructions
 
@@ -188,6 +201,52 @@ Respond with ONLY the following JSON (no markdown fences, no extra text):
 	return b.String()
 }
 
+// writeWorkerResults renders the "Worker Results" section shared by the
+// review and summary prompts.
+func writeWorkerResults(b *strings.Builder, workerResults []WorkerResult) {
+	b.WriteString("## Worker Results\n\n")
+
+	if len(workerResults) == 0 {
+		b.WriteString("No worker results collected.\n\n")
+		return
+	}
+
+	for i, wr := range workerResults {
+		fmt.Fprintf(b, "### Worker %d - %s\n\n", i+1, wr.Outcome)
+		if wr.Summary != "" {
+			fmt.Fprintf(b, "%s\n\n", wr.Summary)
+		}
+		if len(wr.FilesChanged) > 0 {
+			b.WriteString("**Files changed**:\n")
+			for _, f := range wr.FilesChanged {
+				fmt.Fprintf(b, "- %s\n", f)
+			}
+			b.WriteByte('\n')
+		}
+		if len(wr.CommandsRun) > 0 {
+			b.WriteString("**Commands run**:\n")
+			for _, c := range wr.CommandsRun {
+				fmt.Fprintf(b, "- `%s`\n", c)
+			}
+			b.WriteByte('\n')
+		}
+		if len(wr.ValidationResults) > 0 {
+			b.WriteString("**Validation results**:\n")
+			for _, v := range wr.ValidationResults {
+				fmt.Fprintf(b, "- %s\n", v)
+			}
+			b.WriteByte('\n')
+		}
+		if len(wr.Blockers) > 0 {
+			b.WriteString("**Blockers**:\n")
+			for _, bl := range wr.Blockers {
+				fmt.Fprintf(b, "- %s\n", bl)
+			}
+			b.WriteByte('\n')
+		}
+	}
+}
+
 func writeBossContextSection(b *strings.Builder, ctx BossContext) {
 	b.WriteString("\n## Execution Context\n\n")
 	fmt.Fprintf(b, "- **Execution mode**: %s\n", ctx.Mode)
@@ -222,6 +281,14 @@ func writeBossContextSection(b *strings.Builder, ctx BossContext) {
 			fmt.Fprintf(b, "  - %s\n", r)
 		}
 	}
+
+	if len(ctx.UserGuidance) > 0 {
+		b.WriteString("\n## User Guidance\n\n")
+		b.WriteString("Workers escalated blockers during this execution and the user answered them:\n\n")
+		for _, g := range ctx.UserGuidance {
+			fmt.Fprintf(b, "%s\n\n", g)
+		}
+	}
 }
 
 func writeBossOutputFormats(b *strings.Builder) {
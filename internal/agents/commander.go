@@ -14,6 +14,7 @@ type CommanderContext struct {
 	Threads      []db.Thread
 	TaskHistory  []db.TaskHistoryEntry
 	PastRuns     []db.AgentRun
+	PastResults  []db.WorkerResult // structured results of the worker PastRuns
 	Lessons      []db.AgentLesson
 }
 
@@ -42,7 +43,7 @@ Constraints:
 	writeCrewsSection(&b, ctx.Crews)
 	writeThreadsSection(&b, ctx.Threads)
 	writeTaskHistorySection(&b, ctx.TaskHistory)
-	writePastRunsSection(&b, ctx.PastRuns)
+	writePastRunsSection(&b, ctx.PastRuns, ctx.PastResults)
 	writeLessonsSection(&b, ctx.Lessons)
 	writeCommanderOutputFormats(&b)
 
@@ -264,12 +265,18 @@ func writeTaskHistorySection(b *strings.Builder, history []db.TaskHistoryEntry)
 	}
 }
 
-func writePastRunsSection(b *strings.Builder, runs []db.AgentRun) {
+func writePastRunsSection(b *strings.Builder, runs []db.AgentRun, results []db.WorkerResult) {
 	b.WriteString("\n## Recent Past Runs\n\n")
 	if len(runs) == 0 {
 		b.WriteString("No recent past runs.\n")
 		return
 	}
+	byRun := make(map[int64]db.WorkerResult, len(results))
+	for _, wr := range results {
+		if wr.AgentRunID != nil {
+			byRun[*wr.AgentRunID] = wr
+		}
+	}
 	for _, r := range runs {
 		fmt.Fprintf(b, "### %s (%s) - %s\n", r.Role, r.AgentType, r.Outcome)
 		if r.Summary != "" {
@@ -278,10 +285,21 @@ func writePastRunsSection(b *strings.Builder, runs []db.AgentRun) {
 		if r.FilesChanged != "" {
 			fmt.Fprintf(b, "- **Files changed**: %s\n", r.FilesChanged)
 		}
+		if wr, ok := byRun[r.ID]; ok {
+			writeListLine(b, "Validation", wr.ValidationResults)
+			writeListLine(b, "Blockers", wr.Blockers)
+			writeListLine(b, "Notes", wr.Notes)
+		}
 		b.WriteByte('\n')
 	}
 }
 
+func writeListLine(b *strings.Builder, label string, items []string) {
+	if len(items) > 0 {
+		fmt.Fprintf(b, "- **%s**: %s\n", label, strings.Join(items, "; "))
+	}
+}
+
 func writeLessonsSection(b *strings.Builder, lessons []db.AgentLesson) {
 	b.WriteString("\n## Lessons Learned\n\n")
 	if len(lessons) == 0 {
@@ -343,7 +361,7 @@ You have full access to the cluster context below: brain, crews, threads, task h
 	writeCrewsSection(&b, ctx.Crews)
 	writeThreadsSection(&b, ctx.Threads)
 	writeTaskHistorySection(&b, ctx.TaskHistory)
-	writePastRunsSection(&b, ctx.PastRuns)
+	writePastRunsSection(&b, ctx.PastRuns, ctx.PastResults)
 	writeLessonsSection(&b, ctx.Lessons)
 
 	return b.String()
@@ -0,0 +1,65 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+
+	"bore-tui/internal/db"
+)
+
+// pastExecutions is how many of the most recent executions contribute agent
+// runs and worker results to the Commander's context.
+const pastExecutions = 5
+
+// LoadCommanderContext gathers everything the Commander prompts draw on for
+// a cluster: brain, crews, threads, lessons, task history and the agent runs
+// of its most recent executions.
+func LoadCommanderContext(ctx context.Context, d *db.DB, clusterID int64) (CommanderContext, error) {
+	brain, err := d.GetAllMemory(ctx, clusterID)
+	if err != nil {
+		return CommanderContext{}, fmt.Errorf("get memory: %w", err)
+	}
+	crews, err := d.ListCrews(ctx, clusterID)
+	if err != nil {
+		return CommanderContext{}, fmt.Errorf("list crews: %w", err)
+	}
+	threads, err := d.ListThreads(ctx, clusterID)
+	if err != nil {
+		return CommanderContext{}, fmt.Errorf("list threads: %w", err)
+	}
+	lessons, err := d.ListAllLessons(ctx, clusterID)
+	if err != nil {
+		return CommanderContext{}, fmt.Errorf("list lessons: %w", err)
+	}
+	taskHistory, err := d.ListTaskHistories(ctx, clusterID)
+	if err != nil {
+		return CommanderContext{}, fmt.Errorf("list task histories: %w", err)
+	}
+
+	// Past runs are best-effort: a failure here only thins the context.
+	var pastRuns []db.AgentRun
+	var pastResults []db.WorkerResult
+	if executions, err := d.ListExecutions(ctx, clusterID); err == nil {
+		if len(executions) > pastExecutions {
+			executions = executions[:pastExecutions]
+		}
+		for _, ex := range executions {
+			if runs, err := d.GetAgentRuns(ctx, ex.ID); err == nil {
+				pastRuns = append(pastRuns, runs...)
+			}
+			if results, err := d.ListWorkerResults(ctx, ex.ID); err == nil {
+				pastResults = append(pastResults, results...)
+			}
+		}
+	}
+
+	return CommanderContext{
+		Brain:       brain,
+		Crews:       crews,
+		Threads:     threads,
+		TaskHistory: taskHistory,
+		PastRuns:    pastRuns,
+		PastResults: pastResults,
+		Lessons:     lessons,
+	}, nil
+}
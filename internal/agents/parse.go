@@ -11,9 +11,23 @@ type responseTypeField struct {
 }
 
 // ParseResponse attempts to unmarshal the JSON string into the appropriate
-// response type based on the "type" field.
-// Returns the parsed value and nil error, or nil and an error.
+// response type based on the "type" field, then checks it with Validate.
+// Returns the parsed value and nil error, or nil and an error; a response
+// that parses but breaks its schema yields a *ValidationError.
 func ParseResponse(jsonStr string) (any, error) {
+	v, err := decodeResponse(jsonStr)
+	if err != nil {
+		return nil, err
+	}
+	if err := Validate(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// decodeResponse unmarshals the JSON string into the response type named by
+// its "type" field.
+func decodeResponse(jsonStr string) (any, error) {
 	raw := []byte(jsonStr)
 
 	var probe responseTypeField
@@ -0,0 +1,50 @@
+package agents
+
+import (
+	"strings"
+
+	"bore-tui/internal/db"
+	"bore-tui/internal/process"
+)
+
+// deniedCommands are refused to every worker, whatever its crew's allowed
+// commands say. Executions are merged locally after diff review, so nothing
+// needs pushing. rm is denied outright because a prefix rule cannot catch
+// every spelling of its recursive and force flags, and find because of
+// -delete and -exec rm; workers have the Glob and Grep tools for finding
+// files.
+var deniedCommands = []string{"git push:*", "rm:*", "find:*", "sudo:*"}
+
+// WorkerPermissions returns the tool permissions for a worker. Every worker
+// may read and edit files and is refused deniedCommands. Workers without a
+// crew may run any other command. A crew's workers may run only the crew's
+// allowed commands, or, if the crew lists none, the commands the Boss gave
+// the worker; with neither they get no shell.
+func WorkerPermissions(crew *db.Crew, need WorkerNeed) *process.Permissions {
+	if crew == nil {
+		return process.ReadWrite([]string{"*"}, deniedCommands)
+	}
+	var bash []string
+	for _, c := range strings.Split(crew.AllowedCommands, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			bash = append(bash, commandPattern(c))
+		}
+	}
+	if len(bash) == 0 {
+		for _, c := range need.Commands {
+			if c = strings.TrimSpace(c); c != "" {
+				bash = append(bash, commandPattern(c))
+			}
+		}
+	}
+	return process.ReadWrite(bash, deniedCommands)
+}
+
+// commandPattern turns a command prefix such as "go" or "npm test" into a
+// Bash rule pattern matching it with any arguments.
+func commandPattern(prefix string) string {
+	if prefix == "*" || strings.HasSuffix(prefix, ":*") {
+		return prefix
+	}
+	return prefix + ":*"
+}
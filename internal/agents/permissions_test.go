@@ -0,0 +1,90 @@
+package agents
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"bore-tui/internal/db"
+	"bore-tui/internal/process"
+)
+
+// refused reports whether the CLI would refuse cmd under p's Disallowed
+// rules. A rule "Bash(prefix:*)" matches the prefix followed by any
+// arguments; "Bash" matches every command.
+func refused(p *process.Permissions, cmd string) bool {
+	for _, rule := range p.Disallowed {
+		if rule == process.ToolBash {
+			return true
+		}
+		prefix, ok := strings.CutPrefix(rule, process.ToolBash+"(")
+		if !ok {
+			continue
+		}
+		prefix = strings.TrimSuffix(strings.TrimSuffix(prefix, ")"), ":*")
+		if cmd == prefix || strings.HasPrefix(cmd, prefix+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func TestWorkerPermissionsDenyDestructiveCommands(t *testing.T) {
+	crews := map[string]*db.Crew{
+		"no crew":               nil,
+		"crew without commands": {Name: "docs"},
+		"crew allowing rm":      {Name: "cleanup", AllowedCommands: "rm, find, git"},
+	}
+	commands := []string{
+		"rm -rf build",
+		"rm -fr build",
+		"rm -r -f build",
+		"rm -Rf build",
+		"rm --recursive --force build",
+		"rm build/out.txt",
+		"find . -name '*.o' -delete",
+		"find . -exec rm {} +",
+		"git push origin main",
+		"git push --force",
+		"sudo make install",
+	}
+	for name, crew := range crews {
+		p := WorkerPermissions(crew, WorkerNeed{Commands: []string{"rm", "find"}})
+		if p == nil {
+			t.Fatalf("%s: permissions are nil, so every command is allowed", name)
+		}
+		for _, cmd := range commands {
+			if !refused(p, cmd) {
+				t.Errorf("%s: %q is not refused", name, cmd)
+			}
+		}
+		for _, cmd := range []string{"go test ./...", "git status", "ls -la"} {
+			if refused(p, cmd) {
+				t.Errorf("%s: %q is refused", name, cmd)
+			}
+		}
+	}
+}
+
+func TestWorkerPermissionsWithoutCrewAllowTheShell(t *testing.T) {
+	p := WorkerPermissions(nil, WorkerNeed{})
+	for _, tool := range []string{process.ToolBash, process.ToolEdit, process.ToolRead} {
+		if !slices.Contains(p.Allowed, tool) {
+			t.Errorf("allowed tools %v lack %s", p.Allowed, tool)
+		}
+	}
+}
+
+func TestWorkerPermissionsLimitCrewsToTheirCommands(t *testing.T) {
+	p := WorkerPermissions(&db.Crew{Name: "go", AllowedCommands: "go test, npm run lint:*"}, WorkerNeed{Commands: []string{"make"}})
+	want := []string{process.BashRule("go test:*"), process.BashRule("npm run lint:*")}
+	var got []string
+	for _, rule := range p.Allowed {
+		if strings.HasPrefix(rule, process.ToolBash) {
+			got = append(got, rule)
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("allowed Bash rules = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,46 @@
+package agents
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxRepairEcho bounds how much of the rejected response a repair prompt
+// quotes back to the agent.
+const maxRepairEcho = 8000
+
+// BuildRepairPrompt returns prompt followed by a request to correct a
+// response that could not be used. response is the rejected JSON, or the
+// agent's final text if it gave none; problem is why it was rejected. The
+// agent is told its work stands and only the JSON is wanted.
+func BuildRepairPrompt(prompt, response string, problem error) string {
+	var b strings.Builder
+	b.WriteString(prompt)
+
+	b.WriteString("\n\n## Correct Your Response\n\n")
+	b.WriteString("Your previous response to the request above could not be used:\n\n")
+	var ve *ValidationError
+	if errors.As(problem, &ve) {
+		for _, p := range ve.Problems {
+			fmt.Fprintf(&b, "- %s\n", p)
+		}
+	} else {
+		fmt.Fprintf(&b, "- %v\n", problem)
+	}
+
+	response = strings.TrimSpace(response)
+	if len(response) > maxRepairEcho {
+		response = response[:maxRepairEcho] + "\n[truncated]"
+	}
+	if response != "" {
+		b.WriteString("\nYour previous response was:\n\n")
+		b.WriteString(response)
+		b.WriteString("\n")
+	}
+
+	b.WriteString(`
+Do not repeat or redo any work: everything you did before still stands. Respond with ONLY the corrected JSON object in the format requested above (no markdown fences, no extra text).
+`)
+	return b.String()
+}
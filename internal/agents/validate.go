@@ -0,0 +1,137 @@
+package agents
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"bore-tui/internal/db"
+)
+
+// outcomes and lessonTypes are the values the database accepts for a
+// response's outcome and a lesson's lesson_type.
+var (
+	outcomes    = []string{db.OutcomeSuccess, db.OutcomePartial, db.OutcomeFailed}
+	lessonTypes = []string{db.LessonTypeError, db.LessonTypePattern, db.LessonTypeWarning, db.LessonTypeNote}
+)
+
+// ValidationError reports a response that parsed but does not match the
+// schema of its type.
+type ValidationError struct {
+	Type     string   // the response's "type"
+	Problems []string // one entry per violation, e.g. `outcome: must be one of ...`
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("agents: invalid %s response: %s", e.Type, strings.Join(e.Problems, "; "))
+}
+
+// schema collects the violations found while validating one response.
+type schema struct {
+	problems []string
+}
+
+func (s *schema) fail(field, format string, args ...any) {
+	s.problems = append(s.problems, field+": "+fmt.Sprintf(format, args...))
+}
+
+// required checks that a string field is set.
+func (s *schema) required(field, v string) {
+	if strings.TrimSpace(v) == "" {
+		s.fail(field, "is required")
+	}
+}
+
+// nonEmpty checks that a list field has at least one entry.
+func (s *schema) nonEmpty(field string, n int) {
+	if n == 0 {
+		s.fail(field, "must not be empty")
+	}
+}
+
+// oneOf checks that a string field holds one of allowed.
+func (s *schema) oneOf(field, v string, allowed []string) {
+	if !slices.Contains(allowed, v) {
+		s.fail(field, "must be one of %s, not %q", strings.Join(allowed, ", "), v)
+	}
+}
+
+// workers checks each worker a Boss response asks for.
+func (s *schema) workers(field string, workers []WorkerNeed) {
+	for i, w := range workers {
+		s.required(fmt.Sprintf("%s[%d].role", field, i), w.Role)
+		s.required(fmt.Sprintf("%s[%d].goal", field, i), w.Goal)
+	}
+}
+
+// Validate checks a decoded response against the schema of its type, as
+// ParseResponse does before returning it: required fields are set, enums
+// hold a known value and lists that drive the next step are not empty. It
+// returns a *ValidationError listing every violation, or nil.
+func Validate(resp any) error {
+	var s schema
+	var typ string
+	switch v := resp.(type) {
+	case ClarificationsResponse:
+		typ = "clarifications"
+		// No questions is a valid answer: the task is already clear.
+		for i, q := range v.Questions {
+			s.required(fmt.Sprintf("questions[%d].id", i), q.ID)
+			s.required(fmt.Sprintf("questions[%d].question", i), q.Question)
+		}
+
+	case OptionsResponse:
+		typ = "options"
+		s.nonEmpty("options", len(v.Options))
+		for i, o := range v.Options {
+			s.required(fmt.Sprintf("options[%d].id", i), o.ID)
+			s.required(fmt.Sprintf("options[%d].title", i), o.Title)
+			if o.WorkerBudgetSuggestion < 0 {
+				s.fail(fmt.Sprintf("options[%d].worker_budget_suggestion", i), "must not be negative")
+			}
+		}
+
+	case ExecutionBrief:
+		typ = "execution_brief"
+		s.required("selected_option_id", v.SelectedOptionID)
+		s.required("task_title", v.TaskTitle)
+		if v.WorkerBudget < 0 {
+			s.fail("worker_budget", "must not be negative")
+		}
+
+	case BossPlan:
+		typ = "boss_plan"
+		s.nonEmpty("steps", len(v.Steps))
+		for i, st := range v.Steps {
+			s.required(fmt.Sprintf("steps[%d].id", i), st.ID)
+			s.required(fmt.Sprintf("steps[%d].title", i), st.Title)
+		}
+		s.workers("needs_workers", v.NeedsWorkers)
+
+	case SpawnWorkersRequest:
+		typ = "spawn_workers"
+		s.nonEmpty("workers", len(v.Workers))
+		s.workers("workers", v.Workers)
+
+	case BossSummary:
+		typ = "boss_summary"
+		s.oneOf("outcome", v.Outcome, outcomes)
+		for i, l := range v.Lessons {
+			s.oneOf(fmt.Sprintf("lessons[%d].lesson_type", i), l.LessonType, lessonTypes)
+			s.required(fmt.Sprintf("lessons[%d].content", i), l.Content)
+		}
+
+	case WorkerResult:
+		typ = "worker_result"
+		s.oneOf("outcome", v.Outcome, outcomes)
+		s.required("summary", v.Summary)
+
+	default:
+		return fmt.Errorf("agents: cannot validate response of type %T", resp)
+	}
+
+	if len(s.problems) > 0 {
+		return &ValidationError{Type: typ, Problems: s.problems}
+	}
+	return nil
+}
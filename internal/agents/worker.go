@@ -14,6 +14,8 @@ type WorkerContext struct {
 	SuccessCriteria []string
 	CrewObjective   string
 	CrewConstraints string
+	Mode            string   // "just_get_it_done" or "alert_with_issues"
+	UserGuidance    []string // answers from the user to earlier blockers
 }
 
 // BuildWorkerSystemPrompt returns the Worker's system prompt with injected context.
@@ -80,6 +82,18 @@ func writeWorkerContextSection(b *strings.Builder, ctx WorkerContext) {
 	if ctx.CrewConstraints != "" {
 		fmt.Fprintf(b, "- **Crew constraints**: %s\n", ctx.CrewConstraints)
 	}
+
+	if ctx.Mode == "alert_with_issues" {
+		b.WriteString("- **Execution mode**: alert_with_issues -- if you are blocked, phrase each blocker as a specific question the user can answer; the user will be asked and you will be re-run with the answer.\n")
+	}
+
+	if len(ctx.UserGuidance) > 0 {
+		b.WriteString("\n## User Guidance\n\n")
+		b.WriteString("A previous attempt at this assignment reported blockers. The user answered them as follows; follow this guidance:\n\n")
+		for _, g := range ctx.UserGuidance {
+			fmt.Fprintf(b, "%s\n\n", g)
+		}
+	}
 }
 
 func writeWorkerOutputFormat(b *strings.Builder) {
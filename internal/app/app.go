@@ -3,6 +3,7 @@ package app
 import (
 	"errors"
 	"fmt"
+	"sync"
 
 	"bore-tui/internal/config"
 	"bore-tui/internal/db"
@@ -16,14 +17,17 @@ import (
 type App struct {
 	cluster   *db.Cluster
 	db        *db.DB
-	config    *config.Config
 	state     *config.State
 	repo      *git.Repo
 	logs      *logging.Manager
-	runner    *process.Runner
 	scheduler *process.Scheduler
 	boreDir   string
 	statePath string
+	bus       bus
+
+	mu       sync.RWMutex // guards config and backends, which SaveConfig replaces
+	config   *config.Config
+	backends map[string]process.AgentBackend
 }
 
 // Cluster returns the currently open cluster. Nil if none is open.
@@ -33,7 +37,11 @@ func (a *App) Cluster() *db.Cluster { return a.cluster }
 func (a *App) DB() *db.DB { return a.db }
 
 // Config returns the loaded configuration for the current cluster.
-func (a *App) Config() *config.Config { return a.config }
+func (a *App) Config() *config.Config {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.config
+}
 
 // State returns the lightweight UI state.
 func (a *App) State() *config.State { return a.state }
@@ -44,9 +52,6 @@ func (a *App) Repo() *git.Repo { return a.repo }
 // Logs returns the logging manager for the current cluster.
 func (a *App) Logs() *logging.Manager { return a.logs }
 
-// Runner returns the Claude CLI process runner.
-func (a *App) Runner() *process.Runner { return a.runner }
-
 // Scheduler returns the global worker concurrency scheduler.
 func (a *App) Scheduler() *process.Scheduler { return a.scheduler }
 
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"bore-tui/internal/config"
+	"bore-tui/internal/process"
+)
+
+// Backend returns the agent backend configured for role (config.RoleCommander,
+// RoleBoss or RoleWorker). Roles without a backend of their own use the
+// built-in Claude CLI runner. The backend runs the role's model and falls
+// back through agents.fallback_models when that model is overloaded.
+func (a *App) Backend(role string) process.AgentBackend {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.config == nil {
+		return a.backends[config.BackendClaude]
+	}
+	b, ok := a.backends[a.config.Agents.BackendFor(role)]
+	if !ok {
+		b = a.backends[config.BackendClaude]
+	}
+	return process.WithModels(b, a.config.Agents.ModelsFor(role))
+}
+
+// newRecordDir returns a fresh directory under boreDir/runs for a session of
+// recorded agent runs.
+func newRecordDir(boreDir string) string {
+	return filepath.Join(boreDir, "runs", time.Now().Format("20060102-150405"))
+}
+
+// newBackends creates the built-in Claude CLI backend and every backend
+// named in cfg. With cfg.RecordRuns, every backend but a replay is wrapped
+// in a Recorder writing to recordDir.
+func newBackends(cfg config.AgentsConfig, boreDir, recordDir string) (map[string]process.AgentBackend, error) {
+	backends := map[string]process.AgentBackend{
+		config.BackendClaude: process.NewRunner(cfg.ClaudeCLIPath, cfg.DefaultModel),
+	}
+	for name, bc := range cfg.Backends {
+		var b process.AgentBackend
+		var err error
+		switch bc.Type {
+		case config.BackendTypeClaude:
+			cliPath := bc.CLIPath
+			if cliPath == "" {
+				cliPath = cfg.ClaudeCLIPath
+			}
+			b = process.NewRunner(cliPath, bc.Model)
+		case config.BackendTypeCommand:
+			b, err = process.NewCommandBackend(bc.Command, bc.Model)
+		case config.BackendTypeOpenAI:
+			var key string
+			if bc.APIKeyEnv != "" {
+				key = os.Getenv(bc.APIKeyEnv)
+			}
+			b, err = process.NewOpenAIBackend(bc.BaseURL, key, bc.Model)
+		case config.BackendTypeReplay:
+			dir := bc.Dir
+			if !filepath.IsAbs(dir) {
+				dir = filepath.Join(boreDir, "runs", dir)
+			}
+			b, err = process.NewReplayer(dir)
+		default:
+			err = fmt.Errorf("unknown type %q", bc.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("backend %s: %w", name, err)
+		}
+		backends[name] = b
+	}
+
+	if !cfg.RecordRuns {
+		return backends, nil
+	}
+	for name, b := range backends {
+		if cfg.Backends[name].Type == config.BackendTypeReplay {
+			continue
+		}
+		rec, err := process.NewRecorder(b, recordDir)
+		if err != nil {
+			return nil, fmt.Errorf("backend %s: %w", name, err)
+		}
+		backends[name] = rec
+	}
+	return backends, nil
+}
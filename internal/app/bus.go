@@ -0,0 +1,57 @@
+package app
+
+import (
+	"sync"
+
+	"bore-tui/internal/db"
+)
+
+// busBuffer is the channel capacity for each change subscriber.
+const busBuffer = 256
+
+// bus fans out data changes to every front-end in the process. The zero
+// value is ready to use.
+type bus struct {
+	mu   sync.Mutex
+	subs map[chan db.Change]struct{}
+}
+
+// Subscribe registers a listener for data changes: every committed write
+// to the cluster database, from any front-end or the engine, and the
+// opening of a cluster (db.ChangeCluster). The returned function
+// unsubscribes and closes the channel. Delivery is non-blocking: a
+// subscriber that falls more than busBuffer changes behind misses changes
+// rather than stalling writers, so treat a change as a cue to reload.
+func (a *App) Subscribe() (<-chan db.Change, func()) {
+	ch := make(chan db.Change, busBuffer)
+	a.bus.mu.Lock()
+	if a.bus.subs == nil {
+		a.bus.subs = make(map[chan db.Change]struct{})
+	}
+	a.bus.subs[ch] = struct{}{}
+	a.bus.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			a.bus.mu.Lock()
+			delete(a.bus.subs, ch)
+			a.bus.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Publish delivers c to all subscribers without blocking. The cluster
+// database publishes its writes itself; Publish is for changes made
+// elsewhere.
+func (a *App) Publish(c db.Change) {
+	a.bus.mu.Lock()
+	defer a.bus.mu.Unlock()
+	for ch := range a.bus.subs {
+		select {
+		case ch <- c:
+		default:
+		}
+	}
+}
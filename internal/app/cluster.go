@@ -107,7 +107,7 @@ func (a *App) InitClusterFromClone(ctx context.Context, cloneURL string, destPat
 
 // OpenCluster opens an existing cluster from its repo path.
 // It loads the DB, config, state, git repo, logging, and sets up
-// the Runner and Scheduler.
+// the agent backends and Scheduler.
 func (a *App) OpenCluster(ctx context.Context, repoPath string) error {
 	// Close any previously open cluster to prevent resource leaks.
 	if a.db != nil || a.logs != nil {
@@ -156,7 +156,16 @@ func (a *App) OpenCluster(ctx context.Context, repoPath string) error {
 		return fmt.Errorf("app: init logging: %w", err)
 	}
 
-	runner := process.NewRunner(cfg.Agents.ClaudeCLIPath, cfg.Agents.DefaultModel)
+	recordDir := newRecordDir(boreDir)
+	backends, err := newBackends(cfg.Agents, boreDir, recordDir)
+	if err != nil {
+		logs.Close()
+		database.Close()
+		return fmt.Errorf("app: %w", err)
+	}
+	if cfg.Agents.RecordRuns {
+		logs.System.Info("app: recording agent runs to %s", recordDir)
+	}
 	scheduler := process.NewScheduler(cfg.Agents.MaxTotalWorkers)
 
 	cluster, err := database.GetClusterByPath(ctx, absPath)
@@ -166,13 +175,16 @@ func (a *App) OpenCluster(ctx context.Context, repoPath string) error {
 		return fmt.Errorf("app: find cluster: %w", err)
 	}
 
+	database.OnChange(a.Publish)
 	a.cluster = cluster
 	a.db = database
+	a.mu.Lock()
 	a.config = cfg
+	a.backends = backends
+	a.mu.Unlock()
 	a.state = state
 	a.repo = repo
 	a.logs = logs
-	a.runner = runner
 	a.scheduler = scheduler
 	a.boreDir = boreDir
 	a.statePath = statePath
@@ -182,12 +194,45 @@ func (a *App) OpenCluster(ctx context.Context, repoPath string) error {
 	}
 
 	logs.System.Info("app: cluster opened: %s (id=%d)", cluster.Name, cluster.ID)
+	a.Publish(db.Change{Kind: db.ChangeCluster, ID: cluster.ID})
 
 	_ = addKnownCluster(absPath) // best-effort, ignore error
 
 	return nil
 }
 
+// SaveConfig validates cfg, writes it to the open cluster's config file and
+// applies it. Agent settings and backends take effect from the next agent
+// run, and the worker limit at once; logging settings take effect when the
+// cluster is next opened. With agents.record_runs, runs from then on are
+// recorded to a new session directory.
+func (a *App) SaveConfig(cfg *config.Config) error {
+	if a.boreDir == "" {
+		return fmt.Errorf("app: save config: no cluster open")
+	}
+	if err := config.Validate(cfg); err != nil {
+		return fmt.Errorf("app: save config: %w", err)
+	}
+	recordDir := newRecordDir(a.boreDir)
+	backends, err := newBackends(cfg.Agents, a.boreDir, recordDir)
+	if err != nil {
+		return fmt.Errorf("app: save config: %w", err)
+	}
+	if err := config.Save(cfg, filepath.Join(a.boreDir, "config.json")); err != nil {
+		return fmt.Errorf("app: save config: %w", err)
+	}
+
+	a.mu.Lock()
+	a.config = cfg
+	a.backends = backends
+	a.mu.Unlock()
+	a.scheduler.SetMaxWorkers(cfg.Agents.MaxTotalWorkers)
+	if cfg.Agents.RecordRuns {
+		a.logs.System.Info("app: recording agent runs to %s", recordDir)
+	}
+	return nil
+}
+
 // ListRecentClusters returns clusters from the DB, most recent first.
 func (a *App) ListRecentClusters(ctx context.Context) ([]db.Cluster, error) {
 	if a.db == nil {
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package app
+
+import "os"
+
+// processAlive reports whether a process with the given PID exists. On
+// platforms without signal 0, FindProcess fails for a PID that is gone.
+func processAlive(pid int) bool {
+	_, err := os.FindProcess(pid)
+	return err == nil
+}
@@ -3,14 +3,23 @@ package app
 import (
 	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"bore-tui/internal/db"
 )
 
-// recoverInterrupted detects executions that were running when the app
-// last crashed or was killed, and marks them as interrupted.
-// TODO(v2): Also mark orphaned agent_runs (those without an outcome) as "failed"
-// within the recovered executions. Currently only execution-level recovery is performed.
+// OwnerHeartbeat is how often the process running an execution records that
+// it is still alive. An owner silent for three heartbeats counts as gone
+// even if a process with its PID exists, since the PID may have been reused.
+const OwnerHeartbeat = 30 * time.Second
+
+// recoverInterrupted detects executions that were running or awaiting the
+// user when the process running them crashed or was killed, and marks them
+// as interrupted. Agent runs that were still in progress are marked failed
+// and unanswered questions are closed; a resumed execution asks again.
+// An execution whose owning process is still alive and recently sent a
+// heartbeat, such as a headless "exec start" beside the TUI, is left alone.
 func (a *App) recoverInterrupted(ctx context.Context) error {
 	if a.db == nil || a.cluster == nil {
 		return fmt.Errorf("app: recovery requires an open cluster")
@@ -20,19 +29,43 @@ func (a *App) recoverInterrupted(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("app: query running executions: %w", err)
 	}
+	awaiting, err := a.db.ListExecutionsByStatus(ctx, a.cluster.ID, db.StatusAwaitingUser)
+	if err != nil {
+		return fmt.Errorf("app: query executions awaiting user: %w", err)
+	}
+	running = append(running, awaiting...)
 
+	var recovered int
 	for _, exec := range running {
+		if ownerAlive(exec) {
+			continue
+		}
+		recovered++
 		if err := a.db.UpdateExecutionStatus(ctx, exec.ID, db.StatusInterrupted); err != nil {
 			return fmt.Errorf("app: mark execution %d interrupted: %w", exec.ID, err)
 		}
+		if _, err := a.db.FailInProgressAgentRuns(ctx, exec.ID, "Interrupted: bore-tui exited while the agent was running"); err != nil {
+			return fmt.Errorf("app: fail agent runs of execution %d: %w", exec.ID, err)
+		}
+		if _, err := a.db.CloseOpenQuestions(ctx, exec.ID); err != nil {
+			return fmt.Errorf("app: close questions of execution %d: %w", exec.ID, err)
+		}
 		if a.logs != nil {
 			a.logs.System.Warn("app: recovered interrupted execution id=%d task_id=%d", exec.ID, exec.TaskID)
 		}
 	}
 
-	if len(running) > 0 && a.logs != nil {
-		a.logs.System.Info("app: crash recovery marked %d execution(s) as interrupted", len(running))
+	if recovered > 0 && a.logs != nil {
+		a.logs.System.Info("app: crash recovery marked %d execution(s) as interrupted", recovered)
 	}
 
 	return nil
 }
+
+// ownerAlive reports whether the process that owns exec is still running it.
+func ownerAlive(exec db.Execution) bool {
+	if exec.OwnerPID <= 0 || exec.OwnerSeenAt == nil || time.Since(*exec.OwnerSeenAt) > 3*OwnerHeartbeat {
+		return false
+	}
+	return exec.OwnerPID == os.Getpid() || processAlive(exec.OwnerPID)
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -19,6 +20,195 @@ type AgentsConfig struct {
 	MaxWorkersBasic       int    `json:"max_workers_basic"`
 	MaxWorkersMedium      int    `json:"max_workers_medium"`
 	MaxWorkersComplex     int    `json:"max_workers_complex"`
+	WorkerBudget          int    `json:"worker_budget"`    // total workers a Boss may spawn per execution
+	MaxBossRounds         int    `json:"max_boss_rounds"`  // review rounds after the initial wave
+	OwnershipPolicy       string `json:"ownership_policy"` // OwnershipRecord, OwnershipRevert or OwnershipAsk
+
+	// Backends names agent backends other than the built-in BackendClaude,
+	// which runs ClaudeCLIPath with DefaultModel. RoleBackends picks a
+	// backend by name for a role; roles not listed use BackendClaude.
+	Backends     map[string]BackendConfig `json:"backends,omitempty"`
+	RoleBackends map[string]string        `json:"role_backends,omitempty"`
+
+	// Models picks a model per role, overriding the model of the role's
+	// backend; a crew's model overrides the worker model for its workers.
+	// FallbackModels are tried in order when a run fails because its model
+	// is overloaded or rate limited.
+	Models         map[string]string `json:"models,omitempty"`
+	FallbackModels []string          `json:"fallback_models,omitempty"`
+
+	// RecordRuns records every agent run's prompt, output and file changes
+	// under .bore/runs/{session}/ for replay with a BackendTypeReplay
+	// backend or "bore-tui fake-claude".
+	RecordRuns bool `json:"record_runs,omitempty"`
+
+	// Budget caps what agents may spend per execution, per task and per day.
+	Budget BudgetConfig `json:"budget"`
+
+	// Retry sets how failed Boss and worker runs are retried.
+	Retry RetryConfig `json:"retry"`
+
+	// RepairAttempts bounds how many times a Boss or worker whose response
+	// is missing or breaks its schema is asked to correct it, before the
+	// run counts as malformed and Retry.Malformed applies.
+	RepairAttempts int `json:"repair_attempts"`
+}
+
+// RetryConfig holds a retry policy per class of agent failure.
+type RetryConfig struct {
+	RateLimit RetryPolicy `json:"rate_limit"` // the provider is throttling us
+	Overload  RetryPolicy `json:"overload"`   // the model is overloaded
+	Network   RetryPolicy `json:"network"`    // the provider could not be reached
+	Malformed RetryPolicy `json:"malformed"`  // the response is missing or unparseable
+	Failure   RetryPolicy `json:"failure"`    // any other failure
+}
+
+// RetryPolicy retries a failed run up to Retries times. The wait before
+// retry n is BaseDelaySec * 2^(n-1), capped at MaxDelaySec if that is set,
+// then varied by up to ±Jitter of itself so parallel workers do not retry in
+// lockstep.
+type RetryPolicy struct {
+	Retries      int     `json:"retries"`
+	BaseDelaySec float64 `json:"base_delay_sec"`
+	MaxDelaySec  float64 `json:"max_delay_sec"`
+	Jitter       float64 `json:"jitter"` // 0 to 1
+}
+
+// For returns the policy for a failure class as named by process.Failure:
+// "rate_limit", "overload", "network", "malformed" or "failure".
+func (c RetryConfig) For(class string) RetryPolicy {
+	switch class {
+	case "rate_limit":
+		return c.RateLimit
+	case "overload":
+		return c.Overload
+	case "network":
+		return c.Network
+	case "malformed":
+		return c.Malformed
+	}
+	return c.Failure
+}
+
+// BudgetConfig holds spend and token ceilings. A zero ceiling is no limit.
+// Task ceilings cover every execution of a task; daily ceilings cover every
+// execution in the cluster since local midnight.
+type BudgetConfig struct {
+	MaxExecutionUSD    float64 `json:"max_execution_usd"`
+	MaxExecutionTokens int64   `json:"max_execution_tokens"`
+	MaxTaskUSD         float64 `json:"max_task_usd"`
+	MaxTaskTokens      int64   `json:"max_task_tokens"`
+	MaxDailyUSD        float64 `json:"max_daily_usd"`
+	MaxDailyTokens     int64   `json:"max_daily_tokens"`
+
+	// WarnAt lists fractions of a ceiling, e.g. 0.5 and 0.8, at which a
+	// budget_warning event is recorded.
+	WarnAt []float64 `json:"warn_at"`
+
+	// OnExceeded is what an execution does once a ceiling is reached:
+	// BudgetStop or BudgetAbort.
+	OnExceeded string `json:"on_exceeded"`
+}
+
+// Budget policies. Under BudgetStop an execution starts no further workers
+// and goes straight to the Boss summary, except that a task in
+// alert_with_issues mode first pauses and asks the user whether to carry on
+// past the ceiling. BudgetAbort cancels the execution at once; it can be
+// resumed once the ceiling is raised or, for a daily ceiling, the next day.
+const (
+	BudgetStop  = "stop"
+	BudgetAbort = "abort"
+)
+
+// Limited reports whether any ceiling is set.
+func (b BudgetConfig) Limited() bool {
+	return b.MaxExecutionUSD > 0 || b.MaxExecutionTokens > 0 ||
+		b.MaxTaskUSD > 0 || b.MaxTaskTokens > 0 ||
+		b.MaxDailyUSD > 0 || b.MaxDailyTokens > 0
+}
+
+// BackendConfig describes one agent backend.
+type BackendConfig struct {
+	Type string `json:"type"` // BackendTypeClaude, BackendTypeCommand, BackendTypeOpenAI or BackendTypeReplay
+
+	// Command is the argument template for BackendTypeCommand, executable
+	// first. "{prompt}", "{model}" and "{workdir}" are substituted; without
+	// "{prompt}" the prompt is piped to stdin.
+	Command []string `json:"command,omitempty"`
+
+	// BaseURL is the API root for BackendTypeOpenAI. The API key, if any,
+	// is read from the environment variable named by APIKeyEnv so it never
+	// lands in config.json.
+	BaseURL   string `json:"base_url,omitempty"`
+	APIKeyEnv string `json:"api_key_env,omitempty"`
+
+	// CLIPath is the claude binary for BackendTypeClaude; empty means
+	// ClaudeCLIPath.
+	CLIPath string `json:"cli_path,omitempty"`
+	Model   string `json:"model,omitempty"`
+
+	// Dir is the recording directory for BackendTypeReplay, absolute or
+	// relative to .bore/runs.
+	Dir string `json:"dir,omitempty"`
+}
+
+// Backend types.
+const (
+	BackendTypeClaude  = "claude"  // the Claude CLI
+	BackendTypeCommand = "command" // any CLI run from an argument template
+	BackendTypeOpenAI  = "openai"  // an OpenAI-compatible HTTP API
+	BackendTypeReplay  = "replay"  // recorded runs, served back by prompt
+)
+
+// BackendClaude is the name of the built-in Claude CLI backend.
+const BackendClaude = "claude"
+
+// Agent roles that can be given their own backend and model.
+const (
+	RoleCommander = "commander"
+	RoleBoss      = "boss"
+	RoleWorker    = "worker"
+)
+
+// BackendFor returns the name of the backend configured for role.
+func (c AgentsConfig) BackendFor(role string) string {
+	if name := c.RoleBackends[role]; name != "" {
+		return name
+	}
+	return BackendClaude
+}
+
+// ModelsFor returns the model chain for role: the role's model, empty for
+// its backend's own, followed by the fallback models.
+func (c AgentsConfig) ModelsFor(role string) []string {
+	return append([]string{c.Models[role]}, c.FallbackModels...)
+}
+
+// Ownership policies: what happens to files a worker changed outside its
+// crew's ownership paths. Every policy records the violation and marks the
+// worker's run partial.
+const (
+	OwnershipRecord = "record" // keep the changes
+	OwnershipRevert = "revert" // discard the changes
+	OwnershipAsk    = "ask"    // ask the user whether to keep them
+)
+
+// MaxWorkersFor returns the per-execution worker concurrency cap for a task
+// of the given complexity. Unknown complexities fall back to the basic cap.
+func (c AgentsConfig) MaxWorkersFor(complexity string) int {
+	var n int
+	switch complexity {
+	case "complex":
+		n = c.MaxWorkersComplex
+	case "medium":
+		n = c.MaxWorkersMedium
+	default:
+		n = c.MaxWorkersBasic
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
 }
 
 // GitConfig holds git integration settings.
@@ -58,6 +248,20 @@ func DefaultConfig() Config {
 			MaxWorkersBasic:       1,
 			MaxWorkersMedium:      2,
 			MaxWorkersComplex:     4,
+			WorkerBudget:          6,
+			MaxBossRounds:         3,
+			OwnershipPolicy:       OwnershipRecord,
+			Budget: BudgetConfig{
+				WarnAt:     []float64{0.8},
+				OnExceeded: BudgetStop,
+			},
+			Retry: RetryConfig{
+				RateLimit: RetryPolicy{Retries: 5, BaseDelaySec: 30, MaxDelaySec: 600, Jitter: 0.2},
+				Overload:  RetryPolicy{Retries: 5, BaseDelaySec: 15, MaxDelaySec: 300, Jitter: 0.2},
+				Network:   RetryPolicy{Retries: 3, BaseDelaySec: 5, MaxDelaySec: 60, Jitter: 0.2},
+				Malformed: RetryPolicy{Retries: 1, BaseDelaySec: 1, MaxDelaySec: 1},
+			},
+			RepairAttempts: 2,
 		},
 		Git: GitConfig{
 			WorktreeStrategy: "worktree",
@@ -135,6 +339,28 @@ func Validate(cfg *Config) error {
 		errs = append(errs, fmt.Sprintf("agents.max_workers_complex must be >= 1; got %d", cfg.Agents.MaxWorkersComplex))
 	}
 
+	if cfg.Agents.WorkerBudget < 1 {
+		errs = append(errs, fmt.Sprintf("agents.worker_budget must be >= 1; got %d", cfg.Agents.WorkerBudget))
+	}
+
+	if cfg.Agents.MaxBossRounds < 0 {
+		errs = append(errs, fmt.Sprintf("agents.max_boss_rounds must be >= 0; got %d", cfg.Agents.MaxBossRounds))
+	}
+
+	switch cfg.Agents.OwnershipPolicy {
+	case OwnershipRecord, OwnershipRevert, OwnershipAsk:
+	default:
+		errs = append(errs, fmt.Sprintf("agents.ownership_policy must be one of record, revert, ask; got %q", cfg.Agents.OwnershipPolicy))
+	}
+
+	errs = append(errs, validateBackends(cfg.Agents)...)
+	errs = append(errs, validateBudget(cfg.Agents.Budget)...)
+	errs = append(errs, validateRetry(cfg.Agents.Retry)...)
+
+	if cfg.Agents.RepairAttempts < 0 {
+		errs = append(errs, fmt.Sprintf("agents.repair_attempts must be >= 0; got %d", cfg.Agents.RepairAttempts))
+	}
+
 	if cfg.Agents.CommanderContextLimit < 0 {
 		errs = append(errs, fmt.Sprintf("agents.commander_context_limit must be >= 0; got %d", cfg.Agents.CommanderContextLimit))
 	}
@@ -154,6 +380,133 @@ func Validate(cfg *Config) error {
 	return nil
 }
 
+// validateBudget checks the budget ceilings, warning thresholds and policy.
+func validateBudget(b BudgetConfig) []string {
+	var errs []string
+	ceilings := []struct {
+		key   string
+		value float64
+	}{
+		{"max_execution_usd", b.MaxExecutionUSD},
+		{"max_execution_tokens", float64(b.MaxExecutionTokens)},
+		{"max_task_usd", b.MaxTaskUSD},
+		{"max_task_tokens", float64(b.MaxTaskTokens)},
+		{"max_daily_usd", b.MaxDailyUSD},
+		{"max_daily_tokens", float64(b.MaxDailyTokens)},
+	}
+	for _, c := range ceilings {
+		if c.value < 0 {
+			errs = append(errs, fmt.Sprintf("agents.budget.%s must be >= 0; got %g", c.key, c.value))
+		}
+	}
+	for _, w := range b.WarnAt {
+		if w <= 0 || w >= 1 {
+			errs = append(errs, fmt.Sprintf("agents.budget.warn_at values must be between 0 and 1; got %g", w))
+		}
+	}
+	switch b.OnExceeded {
+	case BudgetStop, BudgetAbort:
+	default:
+		errs = append(errs, fmt.Sprintf("agents.budget.on_exceeded must be one of stop, abort; got %q", b.OnExceeded))
+	}
+	return errs
+}
+
+// validateRetry checks every retry policy.
+func validateRetry(c RetryConfig) []string {
+	var errs []string
+	policies := []struct {
+		class  string
+		policy RetryPolicy
+	}{
+		{"rate_limit", c.RateLimit},
+		{"overload", c.Overload},
+		{"network", c.Network},
+		{"malformed", c.Malformed},
+		{"failure", c.Failure},
+	}
+	for _, p := range policies {
+		if p.policy.Retries < 0 {
+			errs = append(errs, fmt.Sprintf("agents.retry.%s.retries must be >= 0; got %d", p.class, p.policy.Retries))
+		}
+		if p.policy.BaseDelaySec < 0 || p.policy.MaxDelaySec < 0 {
+			errs = append(errs, fmt.Sprintf("agents.retry.%s delays must be >= 0", p.class))
+		}
+		if p.policy.Jitter < 0 || p.policy.Jitter > 1 {
+			errs = append(errs, fmt.Sprintf("agents.retry.%s.jitter must be between 0 and 1; got %g", p.class, p.policy.Jitter))
+		}
+	}
+	return errs
+}
+
+// validateBackends checks the agent backends and the roles assigned to
+// them.
+func validateBackends(a AgentsConfig) []string {
+	var errs []string
+	for _, name := range sortedKeys(a.Backends) {
+		b := a.Backends[name]
+		if name == BackendClaude {
+			errs = append(errs, fmt.Sprintf("agents.backends: %q is reserved for the built-in Claude CLI backend", name))
+			continue
+		}
+		switch b.Type {
+		case BackendTypeClaude:
+		case BackendTypeCommand:
+			if len(b.Command) == 0 || b.Command[0] == "" {
+				errs = append(errs, fmt.Sprintf("agents.backends.%s.command must name an executable", name))
+			}
+		case BackendTypeOpenAI:
+			if b.BaseURL == "" {
+				errs = append(errs, fmt.Sprintf("agents.backends.%s.base_url must be set", name))
+			}
+			if b.Model == "" {
+				errs = append(errs, fmt.Sprintf("agents.backends.%s.model must be set", name))
+			}
+		case BackendTypeReplay:
+			if b.Dir == "" {
+				errs = append(errs, fmt.Sprintf("agents.backends.%s.dir must be set", name))
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("agents.backends.%s.type must be one of claude, command, openai, replay; got %q", name, b.Type))
+		}
+	}
+	for _, role := range sortedKeys(a.RoleBackends) {
+		name := a.RoleBackends[role]
+		switch role {
+		case RoleCommander, RoleBoss, RoleWorker:
+		default:
+			errs = append(errs, fmt.Sprintf("agents.role_backends: role must be one of commander, boss, worker; got %q", role))
+			continue
+		}
+		if _, ok := a.Backends[name]; !ok && name != BackendClaude {
+			errs = append(errs, fmt.Sprintf("agents.role_backends.%s: unknown backend %q", role, name))
+		}
+	}
+	for _, role := range sortedKeys(a.Models) {
+		switch role {
+		case RoleCommander, RoleBoss, RoleWorker:
+		default:
+			errs = append(errs, fmt.Sprintf("agents.models: role must be one of commander, boss, worker; got %q", role))
+		}
+	}
+	for i, m := range a.FallbackModels {
+		if m == "" {
+			errs = append(errs, fmt.Sprintf("agents.fallback_models[%d] must not be empty", i))
+		}
+	}
+	return errs
+}
+
+// sortedKeys returns the keys of m in order, for stable error messages.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // EnsureDefaults fills in zero-value string fields in cfg with their default
 // values. This is a public utility for manually constructed Config values.
 // Numeric fields are intentionally left alone: a zero value may be the
@@ -172,6 +525,12 @@ func EnsureDefaults(cfg *Config) {
 		cfg.Agents.ClaudeCLIPath = d.Agents.ClaudeCLIPath
 	}
 	// DefaultModel intentionally left alone — empty string is a valid value.
+	if cfg.Agents.OwnershipPolicy == "" {
+		cfg.Agents.OwnershipPolicy = d.Agents.OwnershipPolicy
+	}
+	if cfg.Agents.Budget.OnExceeded == "" {
+		cfg.Agents.Budget.OnExceeded = d.Agents.Budget.OnExceeded
+	}
 
 	// Git
 	if cfg.Git.WorktreeStrategy == "" {
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"embed"
 	"fmt"
@@ -15,12 +16,28 @@ var migrationsFS embed.FS
 
 // DB wraps a *sql.DB connection to the bore-tui SQLite database.
 type DB struct {
-	conn *sql.DB
+	conn     *sql.DB
+	path     string
+	onChange func(Change)
 }
 
 // Open creates or opens the SQLite database at dbPath, enables foreign keys,
 // and runs all pending migrations.
 func Open(dbPath string) (*DB, error) {
+	d, err := OpenUnmigrated(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := d.Migrate(context.Background()); err != nil {
+		d.Close()
+		return nil, fmt.Errorf("run migrations: %w", err)
+	}
+	return d, nil
+}
+
+// OpenUnmigrated is like Open but does not apply pending migrations. It is
+// used to report migration status and to migrate explicitly.
+func OpenUnmigrated(dbPath string) (*DB, error) {
 	// Ensure parent directory exists.
 	dir := filepath.Dir(dbPath)
 	if err := os.MkdirAll(dir, 0o755); err != nil {
@@ -47,43 +64,24 @@ func Open(dbPath string) (*DB, error) {
 	// Restrict to a single connection — SQLite does not support concurrent writes.
 	sqlDB.SetMaxOpenConns(1)
 
-	d := &DB{conn: sqlDB}
+	return &DB{conn: sqlDB, path: dbPath}, nil
+}
 
-	if err := d.RunMigrations(); err != nil {
-		sqlDB.Close()
-		return nil, fmt.Errorf("run migrations: %w", err)
-	}
+// OnChange registers fn to be called after every committed write, on the
+// writing goroutine. fn must not block or call back into d. Set it before
+// the DB is shared; nil disables notifications.
+func (d *DB) OnChange(fn func(Change)) {
+	d.onChange = fn
+}
 
-	return d, nil
+// changed reports a committed write to the OnChange hook.
+func (d *DB) changed(c Change) {
+	if d.onChange != nil {
+		d.onChange(c)
+	}
 }
 
 // Close closes the underlying database connection.
 func (d *DB) Close() error {
 	return d.conn.Close()
 }
-
-// RunMigrations reads all embedded SQL migration files and executes them.
-// Migrations use IF NOT EXISTS so they are safe to re-run.
-func (d *DB) RunMigrations() error {
-	entries, err := migrationsFS.ReadDir("migrations")
-	if err != nil {
-		return fmt.Errorf("read migrations dir: %w", err)
-	}
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		content, err := migrationsFS.ReadFile("migrations/" + entry.Name())
-		if err != nil {
-			return fmt.Errorf("read migration %s: %w", entry.Name(), err)
-		}
-
-		if _, err := d.conn.Exec(string(content)); err != nil {
-			return fmt.Errorf("execute migration %s: %w", entry.Name(), err)
-		}
-	}
-
-	return nil
-}
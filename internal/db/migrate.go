@@ -0,0 +1,279 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrSchemaTooNew is returned when the database has migrations applied that
+// this build of bore-tui does not know about, i.e. it was last opened by a
+// newer version. Opening it anyway could corrupt data the newer version
+// relies on.
+var ErrSchemaTooNew = errors.New("database schema is newer than this bore-tui")
+
+// Migration is a numbered schema migration and its state in a database.
+type Migration struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at"` // nil while pending
+	Unknown   bool       `json:"unknown"`    // applied, but not embedded in this build
+}
+
+// embeddedMigration is a migration file compiled into the binary.
+type embeddedMigration struct {
+	version int
+	name    string
+	file    string
+}
+
+// schemaMigrationsDDL creates the table recording applied migrations.
+const schemaMigrationsDDL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+  version INTEGER PRIMARY KEY,
+  name TEXT NOT NULL,
+  applied_at TEXT NOT NULL
+)`
+
+// loadMigrations returns the embedded migrations ordered by version. Files
+// are named NNNN_description.sql; versions must be unique.
+func loadMigrations() ([]embeddedMigration, error) {
+	entries, err := migrationsFS.ReadDir("migrations")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations dir: %w", err)
+	}
+
+	var out []embeddedMigration
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		prefix, rest, ok := strings.Cut(strings.TrimSuffix(entry.Name(), ".sql"), "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: name must start with a version number", entry.Name())
+		}
+		if other, dup := seen[version]; dup {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, entry.Name(), version)
+		}
+		seen[version] = entry.Name()
+		out = append(out, embeddedMigration{version: version, name: rest, file: entry.Name()})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].version < out[j].version })
+	return out, nil
+}
+
+// querier is satisfied by *sql.DB, *sql.Conn and *sql.Tx.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// appliedMigrations returns the migrations recorded in schema_migrations,
+// keyed by version. A database without the table has none applied.
+func appliedMigrations(ctx context.Context, q querier) (map[int]Migration, error) {
+	rows, err := q.QueryContext(ctx,
+		`SELECT name FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'`)
+	if err != nil {
+		return nil, fmt.Errorf("check schema_migrations: %w", err)
+	}
+	exists := rows.Next()
+	rows.Close()
+	applied := make(map[int]Migration)
+	if !exists {
+		return applied, nil
+	}
+
+	rows, err = q.QueryContext(ctx, `SELECT version, name, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return nil, fmt.Errorf("list applied migrations: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var m Migration
+		var appliedAt string
+		if err := rows.Scan(&m.Version, &m.Name, &appliedAt); err != nil {
+			return nil, fmt.Errorf("scan applied migration: %w", err)
+		}
+		t, err := parseTime(appliedAt)
+		if err != nil {
+			return nil, err
+		}
+		m.AppliedAt = &t
+		applied[m.Version] = m
+	}
+	return applied, rows.Err()
+}
+
+// MigrationStatus reports every embedded migration in version order and
+// whether it has been applied, followed by any applied migrations this
+// build does not know about.
+func (d *DB) MigrationStatus(ctx context.Context) ([]Migration, error) {
+	embedded, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(ctx, d.conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Migration
+	for _, em := range embedded {
+		m := Migration{Version: em.version, Name: em.name}
+		if a, ok := applied[em.version]; ok {
+			m.AppliedAt = a.AppliedAt
+			delete(applied, em.version)
+		}
+		out = append(out, m)
+	}
+	var unknown []Migration
+	for _, a := range applied {
+		a.Unknown = true
+		unknown = append(unknown, a)
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Version < unknown[j].Version })
+	return append(out, unknown...), nil
+}
+
+// Migrate applies pending migrations in version order, each exactly once and
+// inside its own transaction. Before changing a database that already holds
+// data it writes a backup next to it and returns the backup's path. It
+// returns ErrSchemaTooNew if the database was migrated by a newer bore-tui.
+//
+// Foreign key enforcement is switched off while migrations run so a
+// migration can rebuild a table (the only way to change a CHECK constraint
+// in SQLite) without cascading deletes to its children; each migration must
+// leave the foreign keys consistent or it is rolled back.
+func (d *DB) Migrate(ctx context.Context) (applied []Migration, backup string, err error) {
+	embedded, err := loadMigrations()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Pin a single connection: PRAGMA foreign_keys is per connection.
+	conn, err := d.conn.Conn(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("migrate: acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	done, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return nil, "", err
+	}
+	latest := 0
+	if len(embedded) > 0 {
+		latest = embedded[len(embedded)-1].version
+	}
+	for v := range done {
+		if v > latest {
+			return nil, "", fmt.Errorf("%w: database is at migration %d, this build knows up to %d", ErrSchemaTooNew, v, latest)
+		}
+	}
+
+	var pending []embeddedMigration
+	for _, em := range embedded {
+		if _, ok := done[em.version]; !ok {
+			pending = append(pending, em)
+		}
+	}
+	if len(pending) == 0 {
+		return nil, "", nil
+	}
+
+	hasData, err := hasUserTables(ctx, conn)
+	if err != nil {
+		return nil, "", err
+	}
+	if hasData && d.path != "" {
+		backup = fmt.Sprintf("%s.pre-%04d-%s.bak", d.path, pending[0].version, time.Now().UTC().Format("20060102T150405Z"))
+		if _, err := conn.ExecContext(ctx, `VACUUM INTO ?`, backup); err != nil {
+			return nil, "", fmt.Errorf("migrate: back up database: %w", err)
+		}
+	}
+
+	if _, err := conn.ExecContext(ctx, schemaMigrationsDDL); err != nil {
+		return nil, backup, fmt.Errorf("migrate: create schema_migrations: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+		return nil, backup, fmt.Errorf("migrate: disable foreign keys: %w", err)
+	}
+	defer func() {
+		if _, ferr := conn.ExecContext(context.Background(), "PRAGMA foreign_keys = ON"); ferr != nil && err == nil {
+			err = fmt.Errorf("migrate: enable foreign keys: %w", ferr)
+		}
+	}()
+
+	for _, em := range pending {
+		m, err := applyMigration(ctx, conn, em)
+		if err != nil {
+			return applied, backup, err
+		}
+		applied = append(applied, m)
+	}
+	return applied, backup, nil
+}
+
+// applyMigration runs one migration and records it in a single transaction.
+func applyMigration(ctx context.Context, conn *sql.Conn, em embeddedMigration) (Migration, error) {
+	content, err := migrationsFS.ReadFile("migrations/" + em.file)
+	if err != nil {
+		return Migration{}, fmt.Errorf("read migration %s: %w", em.file, err)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return Migration{}, fmt.Errorf("migration %s: begin: %w", em.file, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, string(content)); err != nil {
+		return Migration{}, fmt.Errorf("execute migration %s: %w", em.file, err)
+	}
+
+	rows, err := tx.QueryContext(ctx, "PRAGMA foreign_key_check")
+	if err != nil {
+		return Migration{}, fmt.Errorf("migration %s: foreign key check: %w", em.file, err)
+	}
+	violation := rows.Next()
+	rows.Close()
+	if violation {
+		return Migration{}, fmt.Errorf("migration %s: leaves foreign key violations", em.file)
+	}
+
+	ts := now()
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+		em.version, em.name, ts,
+	); err != nil {
+		return Migration{}, fmt.Errorf("migration %s: record: %w", em.file, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return Migration{}, fmt.Errorf("migration %s: commit: %w", em.file, err)
+	}
+
+	appliedAt, err := parseTime(ts)
+	if err != nil {
+		return Migration{}, err
+	}
+	return Migration{Version: em.version, Name: em.name, AppliedAt: &appliedAt}, nil
+}
+
+// hasUserTables reports whether the database holds any tables besides
+// SQLite's own and schema_migrations, i.e. whether it is worth backing up.
+func hasUserTables(ctx context.Context, q querier) (bool, error) {
+	rows, err := q.QueryContext(ctx,
+		`SELECT 1 FROM sqlite_master
+		 WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name != 'schema_migrations'
+		 LIMIT 1`)
+	if err != nil {
+		return false, fmt.Errorf("list tables: %w", err)
+	}
+	defer rows.Close()
+	return rows.Next(), rows.Err()
+}
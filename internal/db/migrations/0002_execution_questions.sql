@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS execution_questions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  execution_id INTEGER NOT NULL,
+  kind TEXT NOT NULL DEFAULT 'blocker' CHECK (kind IN ('blocker','ownership','budget')),
+  agent_role TEXT NOT NULL,
+  question TEXT NOT NULL,
+  answer TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL CHECK (status IN ('open','answered','closed')),
+  created_at TEXT NOT NULL,
+  answered_at TEXT,
+  FOREIGN KEY(execution_id) REFERENCES executions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_questions_exec ON execution_questions(execution_id, status);
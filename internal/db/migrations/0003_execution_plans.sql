@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS execution_plans (
+  execution_id INTEGER PRIMARY KEY,
+  brief_json TEXT NOT NULL DEFAULT '',
+  plan_json TEXT NOT NULL,
+  workers_json TEXT NOT NULL DEFAULT '[]',
+  created_at TEXT NOT NULL,
+  updated_at TEXT NOT NULL,
+  FOREIGN KEY(execution_id) REFERENCES executions(id) ON DELETE CASCADE
+);
@@ -0,0 +1,56 @@
+CREATE TABLE IF NOT EXISTS boss_plans (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  execution_id INTEGER NOT NULL,
+  agent_run_id INTEGER,
+  created_at TEXT NOT NULL,
+  FOREIGN KEY(execution_id) REFERENCES executions(id) ON DELETE CASCADE,
+  FOREIGN KEY(agent_run_id) REFERENCES agent_runs(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS plan_steps (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  plan_id INTEGER NOT NULL,
+  position INTEGER NOT NULL,
+  step_key TEXT NOT NULL DEFAULT '',
+  title TEXT NOT NULL,
+  detail TEXT NOT NULL DEFAULT '',
+  worker_role TEXT NOT NULL DEFAULT '',
+  FOREIGN KEY(plan_id) REFERENCES boss_plans(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS plan_items (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  plan_id INTEGER NOT NULL,
+  kind TEXT NOT NULL CHECK (kind IN ('validation','estimated_file')),
+  position INTEGER NOT NULL,
+  content TEXT NOT NULL,
+  FOREIGN KEY(plan_id) REFERENCES boss_plans(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS worker_results (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  execution_id INTEGER NOT NULL,
+  agent_run_id INTEGER,
+  role TEXT NOT NULL,
+  outcome TEXT NOT NULL CHECK (outcome IN ('success','partial','failed')),
+  summary TEXT NOT NULL,
+  created_at TEXT NOT NULL,
+  FOREIGN KEY(execution_id) REFERENCES executions(id) ON DELETE CASCADE,
+  FOREIGN KEY(agent_run_id) REFERENCES agent_runs(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS worker_result_items (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  worker_result_id INTEGER NOT NULL,
+  kind TEXT NOT NULL CHECK (kind IN ('file_changed','command_run','validation_result','note','blocker')),
+  position INTEGER NOT NULL,
+  content TEXT NOT NULL,
+  FOREIGN KEY(worker_result_id) REFERENCES worker_results(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_boss_plans_exec ON boss_plans(execution_id);
+CREATE INDEX IF NOT EXISTS idx_plan_steps_plan ON plan_steps(plan_id, position);
+CREATE INDEX IF NOT EXISTS idx_plan_items_plan ON plan_items(plan_id, kind, position);
+CREATE INDEX IF NOT EXISTS idx_worker_results_exec ON worker_results(execution_id);
+CREATE INDEX IF NOT EXISTS idx_worker_results_run ON worker_results(agent_run_id);
+CREATE INDEX IF NOT EXISTS idx_worker_result_items_result ON worker_result_items(worker_result_id, kind, position);
@@ -0,0 +1,54 @@
+-- Allow the awaiting_user and cancelled statuses on tasks and executions.
+-- SQLite cannot alter a CHECK constraint, so both tables are rebuilt. The
+-- migration runner disables foreign key enforcement while migrations run,
+-- so dropping the old tables does not cascade to their children.
+
+CREATE TABLE tasks_new (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  cluster_id INTEGER NOT NULL,
+  thread_id INTEGER NOT NULL,
+  title TEXT NOT NULL,
+  prompt TEXT NOT NULL,
+  complexity TEXT NOT NULL CHECK (complexity IN ('basic','medium','complex')),
+  mode TEXT NOT NULL CHECK (mode IN ('just_get_it_done','alert_with_issues')),
+  status TEXT NOT NULL CHECK (status IN ('pending','review','running','awaiting_user','diff_review','completed','failed','interrupted','cancelled')),
+  created_at TEXT NOT NULL,
+  updated_at TEXT NOT NULL,
+  FOREIGN KEY(cluster_id) REFERENCES clusters(id) ON DELETE CASCADE,
+  FOREIGN KEY(thread_id) REFERENCES threads(id) ON DELETE RESTRICT
+);
+
+INSERT INTO tasks_new (id, cluster_id, thread_id, title, prompt, complexity, mode, status, created_at, updated_at)
+  SELECT id, cluster_id, thread_id, title, prompt, complexity, mode, status, created_at, updated_at FROM tasks;
+DROP TABLE tasks;
+ALTER TABLE tasks_new RENAME TO tasks;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_cluster_thread ON tasks(cluster_id, thread_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
+
+CREATE TABLE executions_new (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  task_id INTEGER NOT NULL,
+  cluster_id INTEGER NOT NULL,
+  crew_id INTEGER,
+  base_branch TEXT NOT NULL,
+  exec_branch TEXT NOT NULL,
+  worktree_path TEXT NOT NULL,
+  status TEXT NOT NULL CHECK (status IN ('pending','review','running','awaiting_user','diff_review','completed','failed','interrupted','cancelled')),
+  started_at TEXT,
+  finished_at TEXT,
+  created_at TEXT NOT NULL,
+  updated_at TEXT NOT NULL,
+  FOREIGN KEY(task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+  FOREIGN KEY(cluster_id) REFERENCES clusters(id) ON DELETE CASCADE,
+  FOREIGN KEY(crew_id) REFERENCES crews(id) ON DELETE SET NULL
+);
+
+INSERT INTO executions_new (id, task_id, cluster_id, crew_id, base_branch, exec_branch, worktree_path, status, started_at, finished_at, created_at, updated_at)
+  SELECT id, task_id, cluster_id, crew_id, base_branch, exec_branch, worktree_path, status, started_at, finished_at, created_at, updated_at FROM executions;
+DROP TABLE executions;
+ALTER TABLE executions_new RENAME TO executions;
+
+CREATE INDEX IF NOT EXISTS idx_exec_task ON executions(task_id);
+CREATE INDEX IF NOT EXISTS idx_exec_cluster ON executions(cluster_id);
+CREATE INDEX IF NOT EXISTS idx_exec_status ON executions(cluster_id, status);
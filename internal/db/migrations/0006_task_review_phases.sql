@@ -0,0 +1,19 @@
+-- Record every Commander review phase: the user's answers to the
+-- clarification questions, the execution brief and its approval join the
+-- existing phases. The table is rebuilt to change its CHECK constraint.
+
+CREATE TABLE task_reviews_new (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  task_id INTEGER NOT NULL,
+  phase TEXT NOT NULL CHECK (phase IN ('clarification','answers','options','selection','base_branch','brief','approval')),
+  content TEXT NOT NULL,
+  created_at TEXT NOT NULL,
+  FOREIGN KEY(task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+INSERT INTO task_reviews_new (id, task_id, phase, content, created_at)
+  SELECT id, task_id, phase, content, created_at FROM task_reviews;
+DROP TABLE task_reviews;
+ALTER TABLE task_reviews_new RENAME TO task_reviews;
+
+CREATE INDEX IF NOT EXISTS idx_task_reviews_task ON task_reviews(task_id, id);
@@ -0,0 +1,32 @@
+-- Token usage, cost, model and wall-clock duration of each agent run, as
+-- reported by its backend, and the 'running' outcome for runs that have not
+-- finished. Runs recorded before this migration keep zeros. SQLite cannot
+-- alter a CHECK constraint, so the table is rebuilt.
+
+CREATE TABLE agent_runs_new (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  execution_id INTEGER NOT NULL,
+  agent_type TEXT NOT NULL CHECK (agent_type IN ('boss','worker')),
+  role TEXT NOT NULL,
+  prompt TEXT NOT NULL,
+  summary TEXT NOT NULL,
+  outcome TEXT NOT NULL CHECK (outcome IN ('running','success','partial','failed')),
+  files_changed TEXT NOT NULL DEFAULT '',
+  created_at TEXT NOT NULL,
+  model TEXT NOT NULL DEFAULT '',
+  input_tokens INTEGER NOT NULL DEFAULT 0,
+  output_tokens INTEGER NOT NULL DEFAULT 0,
+  cache_creation_tokens INTEGER NOT NULL DEFAULT 0,
+  cache_read_tokens INTEGER NOT NULL DEFAULT 0,
+  cost_usd REAL NOT NULL DEFAULT 0,
+  duration_ms INTEGER NOT NULL DEFAULT 0,
+  FOREIGN KEY(execution_id) REFERENCES executions(id) ON DELETE CASCADE
+);
+
+INSERT INTO agent_runs_new (id, execution_id, agent_type, role, prompt, summary, outcome, files_changed, created_at)
+  SELECT id, execution_id, agent_type, role, prompt, summary, outcome, files_changed, created_at FROM agent_runs;
+DROP TABLE agent_runs;
+ALTER TABLE agent_runs_new RENAME TO agent_runs;
+
+CREATE INDEX IF NOT EXISTS idx_agent_runs_exec ON agent_runs(execution_id);
+CREATE INDEX IF NOT EXISTS idx_agent_runs_created ON agent_runs(created_at);
@@ -0,0 +1,4 @@
+-- A crew may run its workers on a model of its own, overriding the worker
+-- model in config. Empty means no override.
+
+ALTER TABLE crews ADD COLUMN model TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,8 @@
+-- The PID of the bore-tui process running each execution and when that
+-- process last reported in, so that crash recovery only interrupts
+-- executions whose process has exited. The heartbeat guards against the PID
+-- being reused by an unrelated process after a reboot or a long uptime.
+-- Executions recorded before this migration keep 0 and NULL, meaning unknown.
+
+ALTER TABLE executions ADD COLUMN owner_pid INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE executions ADD COLUMN owner_seen_at TEXT;
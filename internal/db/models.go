@@ -45,24 +45,28 @@ func ValidMode(s string) bool { return validModes[s] }
 // ---------------------------------------------------------------------------
 
 const (
-	StatusPending     = "pending"
-	StatusReview      = "review"
-	StatusRunning     = "running"
-	StatusDiffReview  = "diff_review"
-	StatusCompleted   = "completed"
-	StatusFailed      = "failed"
-	StatusInterrupted = "interrupted"
+	StatusPending      = "pending"
+	StatusReview       = "review"
+	StatusRunning      = "running"
+	StatusAwaitingUser = "awaiting_user"
+	StatusDiffReview   = "diff_review"
+	StatusCompleted    = "completed"
+	StatusFailed       = "failed"
+	StatusInterrupted  = "interrupted"
+	StatusCancelled    = "cancelled"
 )
 
 // validTaskStatuses is the set of allowed task status values.
 var validTaskStatuses = map[string]bool{
-	StatusPending:     true,
-	StatusReview:      true,
-	StatusRunning:     true,
-	StatusDiffReview:  true,
-	StatusCompleted:   true,
-	StatusFailed:      true,
-	StatusInterrupted: true,
+	StatusPending:      true,
+	StatusReview:       true,
+	StatusRunning:      true,
+	StatusAwaitingUser: true,
+	StatusDiffReview:   true,
+	StatusCompleted:    true,
+	StatusFailed:       true,
+	StatusInterrupted:  true,
+	StatusCancelled:    true,
 }
 
 // ValidTaskStatus reports whether s is an allowed task status value.
@@ -70,13 +74,15 @@ func ValidTaskStatus(s string) bool { return validTaskStatuses[s] }
 
 // validExecutionStatuses is the set of allowed execution status values.
 var validExecutionStatuses = map[string]bool{
-	StatusPending:     true,
-	StatusReview:      true,
-	StatusRunning:     true,
-	StatusDiffReview:  true,
-	StatusCompleted:   true,
-	StatusFailed:      true,
-	StatusInterrupted: true,
+	StatusPending:      true,
+	StatusReview:       true,
+	StatusRunning:      true,
+	StatusAwaitingUser: true,
+	StatusDiffReview:   true,
+	StatusCompleted:    true,
+	StatusFailed:       true,
+	StatusInterrupted:  true,
+	StatusCancelled:    true,
 }
 
 // ValidExecutionStatus reports whether s is an allowed execution status value.
@@ -86,11 +92,15 @@ func ValidExecutionStatus(s string) bool { return validExecutionStatuses[s] }
 // Review phase constants
 // ---------------------------------------------------------------------------
 
+// Phases of the Commander review recorded in task_reviews, in flow order.
 const (
-	PhaseClarification = "clarification"
-	PhaseOptions       = "options"
-	PhaseSelection     = "selection"
-	PhaseBaseBranch    = "base_branch"
+	PhaseClarification = "clarification" // Commander's clarifying questions
+	PhaseAnswers       = "answers"       // the user's answers to them
+	PhaseOptions       = "options"       // Commander's execution options
+	PhaseSelection     = "selection"     // the option the user picked
+	PhaseBaseBranch    = "base_branch"   // the branch to work from
+	PhaseBrief         = "brief"         // Commander's execution brief
+	PhaseApproval      = "approval"      // the brief the user approved
 )
 
 // ---------------------------------------------------------------------------
@@ -107,6 +117,7 @@ const (
 // ---------------------------------------------------------------------------
 
 const (
+	OutcomeRunning = "running" // agent runs only: the agent has not finished
 	OutcomeSuccess = "success"
 	OutcomePartial = "partial"
 	OutcomeFailed  = "failed"
@@ -123,6 +134,23 @@ const (
 	LessonTypeNote    = "note"
 )
 
+// ---------------------------------------------------------------------------
+// Question status constants
+// ---------------------------------------------------------------------------
+
+const (
+	QuestionOpen     = "open"
+	QuestionAnswered = "answered"
+	QuestionClosed   = "closed" // the execution stopped before it was answered
+)
+
+// Question kinds: what a question asks the user to decide.
+const (
+	QuestionBlocker   = "blocker"   // a worker reported blockers; an empty answer accepts its result
+	QuestionOwnership = "ownership" // a worker changed files it does not own; "keep" keeps them
+	QuestionBudget    = "budget"    // a budget ceiling was reached; "continue" carries on past it
+)
+
 // ---------------------------------------------------------------------------
 // Event level constants
 // ---------------------------------------------------------------------------
@@ -134,6 +162,40 @@ const (
 	LevelError = "error"
 )
 
+// ---------------------------------------------------------------------------
+// Change kinds
+// ---------------------------------------------------------------------------
+
+// ChangeKind names the kind of record a Change is about.
+type ChangeKind string
+
+const (
+	ChangeCluster   ChangeKind = "cluster"
+	ChangeMemory    ChangeKind = "memory"
+	ChangeCrew      ChangeKind = "crew"
+	ChangeThread    ChangeKind = "thread"
+	ChangeTask      ChangeKind = "task"
+	ChangeExecution ChangeKind = "execution"
+	ChangeEvent     ChangeKind = "event"
+	ChangeAgentRun  ChangeKind = "agent_run"
+	ChangeQuestion  ChangeKind = "question"
+	ChangeReview    ChangeKind = "review"
+	ChangePlan      ChangeKind = "plan"
+	ChangeResult    ChangeKind = "worker_result"
+	ChangeLesson    ChangeKind = "lesson"
+)
+
+// Change reports a committed write. ID is the written row's ID, or 0 when
+// several rows changed. ExecutionID is set for records that belong to an
+// execution, TaskID for task reviews, and Key for commander memory.
+type Change struct {
+	Kind        ChangeKind `json:"kind"`
+	ID          int64      `json:"id,omitempty"`
+	ExecutionID int64      `json:"execution_id,omitempty"`
+	TaskID      int64      `json:"task_id,omitempty"`
+	Key         string     `json:"key,omitempty"`
+}
+
 // Cluster represents a git repository workspace managed by bore-tui.
 type Cluster struct {
 	ID        int64
@@ -161,6 +223,7 @@ type Crew struct {
 	Constraints     string    `json:"constraints"`
 	AllowedCommands string    `json:"allowed_commands"`
 	OwnershipPaths  string    `json:"ownership_paths"`
+	Model           string    `json:"model"` // worker model override; empty for the configured one
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
 }
@@ -191,11 +254,11 @@ type Task struct {
 
 // TaskReview captures review phase data for a task.
 type TaskReview struct {
-	ID        int64
-	TaskID    int64
-	Phase     string // clarification, options, selection, base_branch
-	Content   string
-	CreatedAt time.Time
+	ID        int64     `json:"id"`
+	TaskID    int64     `json:"task_id"`
+	Phase     string    `json:"phase"`   // one of the Phase* constants
+	Content   string    `json:"content"` // JSON for Commander responses, else plain text
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // Execution tracks a single run of a task, potentially by a crew.
@@ -210,6 +273,8 @@ type Execution struct {
 	Status       string     `json:"status"`
 	StartedAt    *time.Time `json:"started_at"`
 	FinishedAt   *time.Time `json:"finished_at"`
+	OwnerPID     int        `json:"owner_pid"`     // process that last started or resumed it; 0 if unknown
+	OwnerSeenAt  *time.Time `json:"owner_seen_at"` // last heartbeat of that process; nil if unknown
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at"`
 }
@@ -235,6 +300,60 @@ type AgentRun struct {
 	Outcome      string    `json:"outcome"`
 	FilesChanged string    `json:"files_changed"`
 	CreatedAt    time.Time `json:"created_at"`
+	Model        string    `json:"model"`
+	Usage
+}
+
+// Usage is the token, cost and time accounting of one agent run, or the
+// sum over several.
+type Usage struct {
+	InputTokens         int64   `json:"input_tokens"`
+	OutputTokens        int64   `json:"output_tokens"`
+	CacheCreationTokens int64   `json:"cache_creation_tokens"`
+	CacheReadTokens     int64   `json:"cache_read_tokens"`
+	CostUSD             float64 `json:"cost_usd"`
+	DurationMS          int64   `json:"duration_ms"`
+}
+
+// Add adds o to u.
+func (u *Usage) Add(o Usage) {
+	u.InputTokens += o.InputTokens
+	u.OutputTokens += o.OutputTokens
+	u.CacheCreationTokens += o.CacheCreationTokens
+	u.CacheReadTokens += o.CacheReadTokens
+	u.CostUSD += o.CostUSD
+	u.DurationMS += o.DurationMS
+}
+
+// Tokens returns the total number of tokens, cached or not.
+func (u Usage) Tokens() int64 {
+	return u.InputTokens + u.OutputTokens + u.CacheCreationTokens + u.CacheReadTokens
+}
+
+// UsageRollup is the usage of the agent runs sharing one grouping key.
+type UsageRollup struct {
+	Key   string `json:"key"`   // execution, task, thread or crew ID, or YYYY-MM-DD
+	Label string `json:"label"` // task title, thread or crew name, or the day
+	Runs  int    `json:"runs"`
+	Usage
+}
+
+// Usage groupings accepted by UsageBy.
+const (
+	UsageByExecution = "execution"
+	UsageByTask      = "task"
+	UsageByThread    = "thread"
+	UsageByCrew      = "crew"
+	UsageByDay       = "day"
+)
+
+// UsageTotals is the usage charged to each budget scope of an execution:
+// the execution itself, every execution of its task, and every execution
+// in its cluster since the start of the day.
+type UsageTotals struct {
+	Execution Usage
+	Task      Usage
+	Day       Usage
 }
 
 // AgentLesson captures a lesson learned during an agent run.
@@ -246,3 +365,69 @@ type AgentLesson struct {
 	Content     string
 	CreatedAt   time.Time
 }
+
+// ExecutionQuestion is a question an execution put to the user, such as a
+// worker blocker in alert_with_issues mode, together with the user's answer
+// once given.
+type ExecutionQuestion struct {
+	ID          int64      `json:"id"`
+	ExecutionID int64      `json:"execution_id"`
+	Kind        string     `json:"kind"` // one of the Question kind constants
+	AgentRole   string     `json:"agent_role"`
+	Question    string     `json:"question"`
+	Answer      string     `json:"answer"`
+	Status      string     `json:"status"` // open, answered, closed
+	CreatedAt   time.Time  `json:"created_at"`
+	AnsweredAt  *time.Time `json:"answered_at"`
+}
+
+// ExecutionPlan is the persisted Boss plan of an execution, used to resume
+// it after an interruption. The JSON columns hold the execution brief, the
+// Boss plan and every worker spawned so far, in spawn order.
+type ExecutionPlan struct {
+	ExecutionID int64     `json:"execution_id"`
+	BriefJSON   string    `json:"brief_json"`
+	PlanJSON    string    `json:"plan_json"`
+	WorkersJSON string    `json:"workers_json"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// BossPlan is the structured record of a Boss plan. Worker needs are kept
+// with the resumable plan in ExecutionPlan.
+type BossPlan struct {
+	ID             int64      `json:"id"`
+	ExecutionID    int64      `json:"execution_id"`
+	AgentRunID     *int64     `json:"agent_run_id"`
+	Steps          []PlanStep `json:"steps"`
+	Validation     []string   `json:"validation"`
+	EstimatedFiles []string   `json:"estimated_files"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// PlanStep is one step of a Boss plan, in plan order.
+type PlanStep struct {
+	ID         int64  `json:"id"`
+	PlanID     int64  `json:"plan_id"`
+	Position   int    `json:"position"`
+	StepKey    string `json:"step_key"` // the step ID chosen by the Boss
+	Title      string `json:"title"`
+	Detail     string `json:"detail"`
+	WorkerRole string `json:"worker_role"`
+}
+
+// WorkerResult is the structured result reported by a worker.
+type WorkerResult struct {
+	ID                int64     `json:"id"`
+	ExecutionID       int64     `json:"execution_id"`
+	AgentRunID        *int64    `json:"agent_run_id"`
+	Role              string    `json:"role"`
+	Outcome           string    `json:"outcome"`
+	Summary           string    `json:"summary"`
+	FilesChanged      []string  `json:"files_changed"`
+	CommandsRun       []string  `json:"commands_run"`
+	ValidationResults []string  `json:"validation_results"`
+	Notes             []string  `json:"notes"`
+	Blockers          []string  `json:"blockers"`
+	CreatedAt         time.Time `json:"created_at"`
+}
@@ -103,6 +103,7 @@ func (d *DB) CreateCluster(ctx context.Context, name, repoPath, remoteURL string
 	if err != nil {
 		return nil, fmt.Errorf("create cluster: parse time: %w", err)
 	}
+	d.changed(Change{Kind: ChangeCluster, ID: id})
 	return &Cluster{
 		ID:        id,
 		Name:      name,
@@ -169,6 +170,7 @@ func (d *DB) DeleteCluster(ctx context.Context, id int64) error {
 	if n == 0 {
 		return fmt.Errorf("delete cluster (id=%d): %w", id, ErrNotFound)
 	}
+	d.changed(Change{Kind: ChangeCluster, ID: id})
 	return nil
 }
 
@@ -204,6 +206,7 @@ func (d *DB) SetMemory(ctx context.Context, clusterID int64, key, value string)
 	if err != nil {
 		return fmt.Errorf("set memory: %w", err)
 	}
+	d.changed(Change{Kind: ChangeMemory, ID: clusterID, Key: key})
 	return nil
 }
 
@@ -264,6 +267,7 @@ func (d *DB) DeleteMemory(ctx context.Context, clusterID int64, key string) erro
 	if n == 0 {
 		return fmt.Errorf("delete memory (cluster_id=%d, key=%q): %w", clusterID, key, ErrNotFound)
 	}
+	d.changed(Change{Kind: ChangeMemory, ID: clusterID, Key: key})
 	return nil
 }
 
@@ -272,12 +276,12 @@ func (d *DB) DeleteMemory(ctx context.Context, clusterID int64, key string) erro
 // ---------------------------------------------------------------------------
 
 // CreateCrew inserts a new crew and returns it.
-func (d *DB) CreateCrew(ctx context.Context, clusterID int64, name, objective, constraints, allowedCommands, ownershipPaths string) (*Crew, error) {
+func (d *DB) CreateCrew(ctx context.Context, clusterID int64, name, objective, constraints, allowedCommands, ownershipPaths, model string) (*Crew, error) {
 	ts := now()
 	res, err := d.conn.ExecContext(ctx,
-		`INSERT INTO crews (cluster_id, name, objective, constraints, allowed_commands, ownership_paths, created_at, updated_at)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
-		clusterID, name, objective, constraints, allowedCommands, ownershipPaths, ts, ts,
+		`INSERT INTO crews (cluster_id, name, objective, constraints, allowed_commands, ownership_paths, model, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		clusterID, name, objective, constraints, allowedCommands, ownershipPaths, model, ts, ts,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("create crew: %w", err)
@@ -290,6 +294,7 @@ func (d *DB) CreateCrew(ctx context.Context, clusterID int64, name, objective, c
 	if err != nil {
 		return nil, fmt.Errorf("create crew: parse time: %w", err)
 	}
+	d.changed(Change{Kind: ChangeCrew, ID: id})
 	return &Crew{
 		ID:              id,
 		ClusterID:       clusterID,
@@ -298,6 +303,7 @@ func (d *DB) CreateCrew(ctx context.Context, clusterID int64, name, objective, c
 		Constraints:     constraints,
 		AllowedCommands: allowedCommands,
 		OwnershipPaths:  ownershipPaths,
+		Model:           model,
 		CreatedAt:       createdAt,
 		UpdatedAt:       createdAt,
 	}, nil
@@ -306,7 +312,7 @@ func (d *DB) CreateCrew(ctx context.Context, clusterID int64, name, objective, c
 // GetCrew returns a crew by ID.
 func (d *DB) GetCrew(ctx context.Context, id int64) (*Crew, error) {
 	row := d.conn.QueryRowContext(ctx,
-		`SELECT id, cluster_id, name, objective, constraints, allowed_commands, ownership_paths, created_at, updated_at
+		`SELECT id, cluster_id, name, objective, constraints, allowed_commands, ownership_paths, model, created_at, updated_at
 		 FROM crews WHERE id = ?`, id,
 	)
 	return scanCrew(row)
@@ -315,7 +321,7 @@ func (d *DB) GetCrew(ctx context.Context, id int64) (*Crew, error) {
 // ListCrews returns all crews for a cluster.
 func (d *DB) ListCrews(ctx context.Context, clusterID int64) ([]Crew, error) {
 	rows, err := d.conn.QueryContext(ctx,
-		`SELECT id, cluster_id, name, objective, constraints, allowed_commands, ownership_paths, created_at, updated_at
+		`SELECT id, cluster_id, name, objective, constraints, allowed_commands, ownership_paths, model, created_at, updated_at
 		 FROM crews WHERE cluster_id = ? ORDER BY name`,
 		clusterID,
 	)
@@ -339,9 +345,9 @@ func (d *DB) ListCrews(ctx context.Context, clusterID int64) ([]Crew, error) {
 func (d *DB) UpdateCrew(ctx context.Context, crew *Crew) error {
 	ts := now()
 	_, err := d.conn.ExecContext(ctx,
-		`UPDATE crews SET name = ?, objective = ?, constraints = ?, allowed_commands = ?, ownership_paths = ?, updated_at = ?
+		`UPDATE crews SET name = ?, objective = ?, constraints = ?, allowed_commands = ?, ownership_paths = ?, model = ?, updated_at = ?
 		 WHERE id = ?`,
-		crew.Name, crew.Objective, crew.Constraints, crew.AllowedCommands, crew.OwnershipPaths, ts, crew.ID,
+		crew.Name, crew.Objective, crew.Constraints, crew.AllowedCommands, crew.OwnershipPaths, crew.Model, ts, crew.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("update crew: %w", err)
@@ -351,6 +357,7 @@ func (d *DB) UpdateCrew(ctx context.Context, crew *Crew) error {
 		return fmt.Errorf("update crew: parse time: %w", err)
 	}
 	crew.UpdatedAt = updatedAt
+	d.changed(Change{Kind: ChangeCrew, ID: crew.ID})
 	return nil
 }
 
@@ -367,6 +374,7 @@ func (d *DB) DeleteCrew(ctx context.Context, id int64) error {
 	if n == 0 {
 		return fmt.Errorf("delete crew (id=%d): %w", id, ErrNotFound)
 	}
+	d.changed(Change{Kind: ChangeCrew, ID: id})
 	return nil
 }
 
@@ -374,7 +382,7 @@ func scanCrew(s scanner) (*Crew, error) {
 	var c Crew
 	var createdAt, updatedAt string
 	if err := s.Scan(&c.ID, &c.ClusterID, &c.Name, &c.Objective, &c.Constraints,
-		&c.AllowedCommands, &c.OwnershipPaths, &createdAt, &updatedAt); err != nil {
+		&c.AllowedCommands, &c.OwnershipPaths, &c.Model, &createdAt, &updatedAt); err != nil {
 		return nil, fmt.Errorf("scan crew: %w", err)
 	}
 	var err error
@@ -412,6 +420,7 @@ func (d *DB) CreateThread(ctx context.Context, clusterID int64, name, descriptio
 	if err != nil {
 		return nil, fmt.Errorf("create thread: parse time: %w", err)
 	}
+	d.changed(Change{Kind: ChangeThread, ID: id})
 	return &Thread{
 		ID:          id,
 		ClusterID:   clusterID,
@@ -469,6 +478,7 @@ func (d *DB) UpdateThread(ctx context.Context, thread *Thread) error {
 		return fmt.Errorf("update thread: parse time: %w", err)
 	}
 	thread.UpdatedAt = updatedAt
+	d.changed(Change{Kind: ChangeThread, ID: thread.ID})
 	return nil
 }
 
@@ -519,6 +529,7 @@ func (d *DB) CreateTask(ctx context.Context, clusterID, threadID int64, title, p
 	if err != nil {
 		return nil, fmt.Errorf("create task: parse time: %w", err)
 	}
+	d.changed(Change{Kind: ChangeTask, ID: id})
 	return &Task{
 		ID:         id,
 		ClusterID:  clusterID,
@@ -655,6 +666,7 @@ func (d *DB) UpdateTaskStatus(ctx context.Context, id int64, status string) erro
 	if n == 0 {
 		return fmt.Errorf("update task status (id=%d): %w", id, ErrNotFound)
 	}
+	d.changed(Change{Kind: ChangeTask, ID: id})
 	return nil
 }
 
@@ -712,6 +724,7 @@ func (d *DB) CreateTaskReview(ctx context.Context, taskID int64, phase, content
 	if err != nil {
 		return nil, fmt.Errorf("create task review: parse time: %w", err)
 	}
+	d.changed(Change{Kind: ChangeReview, ID: id, TaskID: taskID})
 	return &TaskReview{
 		ID:        id,
 		TaskID:    taskID,
@@ -725,7 +738,7 @@ func (d *DB) CreateTaskReview(ctx context.Context, taskID int64, phase, content
 func (d *DB) GetTaskReviews(ctx context.Context, taskID int64) ([]TaskReview, error) {
 	rows, err := d.conn.QueryContext(ctx,
 		`SELECT id, task_id, phase, content, created_at
-		 FROM task_reviews WHERE task_id = ? ORDER BY created_at`,
+		 FROM task_reviews WHERE task_id = ? ORDER BY created_at, id`,
 		taskID,
 	)
 	if err != nil {
@@ -772,6 +785,7 @@ func (d *DB) CreateExecution(ctx context.Context, taskID, clusterID int64, crewI
 	if err != nil {
 		return nil, fmt.Errorf("create execution: parse time: %w", err)
 	}
+	d.changed(Change{Kind: ChangeExecution, ID: id, ExecutionID: id})
 	return &Execution{
 		ID:           id,
 		TaskID:       taskID,
@@ -790,7 +804,7 @@ func (d *DB) CreateExecution(ctx context.Context, taskID, clusterID int64, crewI
 func (d *DB) GetExecution(ctx context.Context, id int64) (*Execution, error) {
 	row := d.conn.QueryRowContext(ctx,
 		`SELECT id, task_id, cluster_id, crew_id, base_branch, exec_branch, worktree_path,
-		        status, started_at, finished_at, created_at, updated_at
+		        status, started_at, finished_at, owner_pid, owner_seen_at, created_at, updated_at
 		 FROM executions WHERE id = ?`, id,
 	)
 	return scanExecution(row)
@@ -800,7 +814,7 @@ func (d *DB) GetExecution(ctx context.Context, id int64) (*Execution, error) {
 func (d *DB) ListExecutions(ctx context.Context, clusterID int64) ([]Execution, error) {
 	rows, err := d.conn.QueryContext(ctx,
 		`SELECT id, task_id, cluster_id, crew_id, base_branch, exec_branch, worktree_path,
-		        status, started_at, finished_at, created_at, updated_at
+		        status, started_at, finished_at, owner_pid, owner_seen_at, created_at, updated_at
 		 FROM executions WHERE cluster_id = ? ORDER BY created_at DESC`,
 		clusterID,
 	)
@@ -815,7 +829,7 @@ func (d *DB) ListExecutions(ctx context.Context, clusterID int64) ([]Execution,
 func (d *DB) ListExecutionsByStatus(ctx context.Context, clusterID int64, status string) ([]Execution, error) {
 	rows, err := d.conn.QueryContext(ctx,
 		`SELECT id, task_id, cluster_id, crew_id, base_branch, exec_branch, worktree_path,
-		        status, started_at, finished_at, created_at, updated_at
+		        status, started_at, finished_at, owner_pid, owner_seen_at, created_at, updated_at
 		 FROM executions WHERE cluster_id = ? AND status = ? ORDER BY created_at DESC`,
 		clusterID, status,
 	)
@@ -846,15 +860,18 @@ func (d *DB) UpdateExecutionStatus(ctx context.Context, id int64, status string)
 	if n == 0 {
 		return fmt.Errorf("update execution status (id=%d): %w", id, ErrNotFound)
 	}
+	d.changed(Change{Kind: ChangeExecution, ID: id, ExecutionID: id})
 	return nil
 }
 
-// SetExecutionStarted records the start time and sets status to "running".
-func (d *DB) SetExecutionStarted(ctx context.Context, id int64) error {
+// SetExecutionStarted records the start time and the PID of the process
+// running the execution, and sets status to "running". The start counts as
+// the owner's first heartbeat.
+func (d *DB) SetExecutionStarted(ctx context.Context, id int64, ownerPID int) error {
 	ts := now()
 	res, err := d.conn.ExecContext(ctx,
-		`UPDATE executions SET status = 'running', started_at = ?, updated_at = ? WHERE id = ?`,
-		ts, ts, id,
+		`UPDATE executions SET status = 'running', started_at = ?, owner_pid = ?, owner_seen_at = ?, updated_at = ? WHERE id = ?`,
+		ts, ownerPID, ts, ts, id,
 	)
 	if err != nil {
 		return fmt.Errorf("set execution started: %w", err)
@@ -866,6 +883,42 @@ func (d *DB) SetExecutionStarted(ctx context.Context, id int64) error {
 	if n == 0 {
 		return fmt.Errorf("set execution started (id=%d): %w", id, ErrNotFound)
 	}
+	d.changed(Change{Kind: ChangeExecution, ID: id, ExecutionID: id})
+	return nil
+}
+
+// ResumeExecution marks a stopped execution running again in the process
+// ownerPID and clears its finish time. The original start time is kept.
+func (d *DB) ResumeExecution(ctx context.Context, id int64, ownerPID int) error {
+	ts := now()
+	res, err := d.conn.ExecContext(ctx,
+		`UPDATE executions SET status = 'running', finished_at = NULL, owner_pid = ?, owner_seen_at = ?, updated_at = ? WHERE id = ?`,
+		ownerPID, ts, ts, id,
+	)
+	if err != nil {
+		return fmt.Errorf("resume execution: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("resume execution: rows affected: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("resume execution (id=%d): %w", id, ErrNotFound)
+	}
+	d.changed(Change{Kind: ChangeExecution, ID: id, ExecutionID: id})
+	return nil
+}
+
+// TouchExecutionOwner records a heartbeat from the process running an
+// execution. It does nothing if another process has since taken the
+// execution over. No change is published: the heartbeat is not visible.
+func (d *DB) TouchExecutionOwner(ctx context.Context, id int64, ownerPID int) error {
+	if _, err := d.conn.ExecContext(ctx,
+		`UPDATE executions SET owner_seen_at = ? WHERE id = ? AND owner_pid = ?`,
+		now(), id, ownerPID,
+	); err != nil {
+		return fmt.Errorf("touch execution owner: %w", err)
+	}
 	return nil
 }
 
@@ -889,17 +942,36 @@ func (d *DB) SetExecutionFinished(ctx context.Context, id int64, status string)
 	if n == 0 {
 		return fmt.Errorf("set execution finished (id=%d): %w", id, ErrNotFound)
 	}
+	d.changed(Change{Kind: ChangeExecution, ID: id, ExecutionID: id})
+	return nil
+}
+
+// DeleteExecution removes an execution by ID, along with everything
+// recorded for it.
+func (d *DB) DeleteExecution(ctx context.Context, id int64) error {
+	res, err := d.conn.ExecContext(ctx, `DELETE FROM executions WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete execution: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete execution: rows affected: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("delete execution (id=%d): %w", id, ErrNotFound)
+	}
+	d.changed(Change{Kind: ChangeExecution, ID: id, ExecutionID: id})
 	return nil
 }
 
 func scanExecution(s scanner) (*Execution, error) {
 	var e Execution
 	var crewID sql.NullInt64
-	var startedAt, finishedAt sql.NullString
+	var startedAt, finishedAt, ownerSeenAt sql.NullString
 	var createdAt, updatedAt string
 	if err := s.Scan(&e.ID, &e.TaskID, &e.ClusterID, &crewID,
 		&e.BaseBranch, &e.ExecBranch, &e.WorktreePath,
-		&e.Status, &startedAt, &finishedAt, &createdAt, &updatedAt); err != nil {
+		&e.Status, &startedAt, &finishedAt, &e.OwnerPID, &ownerSeenAt, &createdAt, &updatedAt); err != nil {
 		return nil, fmt.Errorf("scan execution: %w", err)
 	}
 	e.CrewID = nullableInt64ToPtr(crewID)
@@ -912,6 +984,10 @@ func scanExecution(s scanner) (*Execution, error) {
 	if err != nil {
 		return nil, err
 	}
+	e.OwnerSeenAt, err = parseNullableTime(ownerSeenAt)
+	if err != nil {
+		return nil, err
+	}
 	e.CreatedAt, err = parseTime(createdAt)
 	if err != nil {
 		return nil, err
@@ -942,7 +1018,7 @@ func collectExecutions(rows *sql.Rows) ([]Execution, error) {
 // CreateEvent inserts a timestamped event for an execution.
 func (d *DB) CreateEvent(ctx context.Context, executionID int64, level, eventType, message string) error {
 	ts := now()
-	_, err := d.conn.ExecContext(ctx,
+	res, err := d.conn.ExecContext(ctx,
 		`INSERT INTO execution_events (execution_id, ts, level, event_type, message)
 		 VALUES (?, ?, ?, ?, ?)`,
 		executionID, ts, level, eventType, message,
@@ -950,6 +1026,11 @@ func (d *DB) CreateEvent(ctx context.Context, executionID int64, level, eventTyp
 	if err != nil {
 		return fmt.Errorf("create event: %w", err)
 	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("create event: last insert id: %w", err)
+	}
+	d.changed(Change{Kind: ChangeEvent, ID: id, ExecutionID: executionID})
 	return nil
 }
 
@@ -964,7 +1045,26 @@ func (d *DB) ListEvents(ctx context.Context, executionID int64) ([]ExecutionEven
 		return nil, fmt.Errorf("list events: %w", err)
 	}
 	defer rows.Close()
+	return collectEvents(rows)
+}
 
+// ListEventsAfter returns the events for an execution with an ID greater
+// than afterID, in insertion order. Event IDs only grow, so callers can
+// page through new events by passing the last ID they saw.
+func (d *DB) ListEventsAfter(ctx context.Context, executionID, afterID int64) ([]ExecutionEvent, error) {
+	rows, err := d.conn.QueryContext(ctx,
+		`SELECT id, execution_id, ts, level, event_type, message
+		 FROM execution_events WHERE execution_id = ? AND id > ? ORDER BY id`,
+		executionID, afterID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list events after: %w", err)
+	}
+	defer rows.Close()
+	return collectEvents(rows)
+}
+
+func collectEvents(rows *sql.Rows) ([]ExecutionEvent, error) {
 	var out []ExecutionEvent
 	for rows.Next() {
 		var ev ExecutionEvent
@@ -972,6 +1072,7 @@ func (d *DB) ListEvents(ctx context.Context, executionID int64) ([]ExecutionEven
 		if err := rows.Scan(&ev.ID, &ev.ExecutionID, &ts, &ev.Level, &ev.EventType, &ev.Message); err != nil {
 			return nil, fmt.Errorf("scan event: %w", err)
 		}
+		var err error
 		ev.Ts, err = parseTime(ts)
 		if err != nil {
 			return nil, err
@@ -1004,6 +1105,7 @@ func (d *DB) CreateAgentRun(ctx context.Context, executionID int64, agentType, r
 	if err != nil {
 		return nil, fmt.Errorf("create agent run: parse time: %w", err)
 	}
+	d.changed(Change{Kind: ChangeAgentRun, ID: id, ExecutionID: executionID})
 	return &AgentRun{
 		ID:           id,
 		ExecutionID:  executionID,
@@ -1020,7 +1122,8 @@ func (d *DB) CreateAgentRun(ctx context.Context, executionID int64, agentType, r
 // GetAgentRuns returns all agent runs for an execution.
 func (d *DB) GetAgentRuns(ctx context.Context, executionID int64) ([]AgentRun, error) {
 	rows, err := d.conn.QueryContext(ctx,
-		`SELECT id, execution_id, agent_type, role, prompt, summary, outcome, files_changed, created_at
+		`SELECT id, execution_id, agent_type, role, prompt, summary, outcome, files_changed, created_at,
+		        model, input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens, cost_usd, duration_ms
 		 FROM agent_runs WHERE execution_id = ? ORDER BY created_at`,
 		executionID,
 	)
@@ -1034,7 +1137,8 @@ func (d *DB) GetAgentRuns(ctx context.Context, executionID int64) ([]AgentRun, e
 // GetAgentRunsByType returns agent runs filtered by type (boss or worker).
 func (d *DB) GetAgentRunsByType(ctx context.Context, executionID int64, agentType string) ([]AgentRun, error) {
 	rows, err := d.conn.QueryContext(ctx,
-		`SELECT id, execution_id, agent_type, role, prompt, summary, outcome, files_changed, created_at
+		`SELECT id, execution_id, agent_type, role, prompt, summary, outcome, files_changed, created_at,
+		        model, input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens, cost_usd, duration_ms
 		 FROM agent_runs WHERE execution_id = ? AND agent_type = ? ORDER BY created_at`,
 		executionID, agentType,
 	)
@@ -1045,11 +1149,163 @@ func (d *DB) GetAgentRunsByType(ctx context.Context, executionID int64, agentTyp
 	return collectAgentRuns(rows)
 }
 
+// UpdateAgentRun records the final summary, outcome and changed files of an
+// agent run created while the agent was still running.
+func (d *DB) UpdateAgentRun(ctx context.Context, id int64, summary, outcome, filesChanged string) error {
+	res, err := d.conn.ExecContext(ctx,
+		`UPDATE agent_runs SET summary = ?, outcome = ?, files_changed = ? WHERE id = ?`,
+		summary, outcome, filesChanged, id,
+	)
+	if err != nil {
+		return fmt.Errorf("update agent run: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update agent run: rows affected: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("update agent run (id=%d): %w", id, ErrNotFound)
+	}
+	d.changed(Change{Kind: ChangeAgentRun, ID: id})
+	return nil
+}
+
+// SetAgentRunUsage records the model, token usage, cost and duration of an
+// agent run.
+func (d *DB) SetAgentRunUsage(ctx context.Context, id int64, model string, u Usage) error {
+	res, err := d.conn.ExecContext(ctx,
+		`UPDATE agent_runs SET model = ?, input_tokens = ?, output_tokens = ?,
+		   cache_creation_tokens = ?, cache_read_tokens = ?, cost_usd = ?, duration_ms = ?
+		 WHERE id = ?`,
+		model, u.InputTokens, u.OutputTokens, u.CacheCreationTokens, u.CacheReadTokens,
+		u.CostUSD, u.DurationMS, id,
+	)
+	if err != nil {
+		return fmt.Errorf("set agent run usage: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("set agent run usage: rows affected: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("set agent run usage (id=%d): %w", id, ErrNotFound)
+	}
+	d.changed(Change{Kind: ChangeAgentRun, ID: id})
+	return nil
+}
+
+// usageGroupings maps each UsageBy grouping to its key and label
+// expressions and the joins they need beyond agent_runs ar, executions e
+// and tasks t.
+var usageGroupings = map[string]struct{ key, label, join string }{
+	UsageByExecution: {"CAST(e.id AS TEXT)", "t.title", ""},
+	UsageByTask:      {"CAST(t.id AS TEXT)", "t.title", ""},
+	UsageByThread:    {"CAST(t.thread_id AS TEXT)", "COALESCE(th.name, '')", "LEFT JOIN threads th ON th.id = t.thread_id"},
+	UsageByCrew:      {"CAST(COALESCE(e.crew_id, 0) AS TEXT)", "COALESCE(c.name, '(no crew)')", "LEFT JOIN crews c ON c.id = e.crew_id"},
+	UsageByDay:       {"substr(ar.created_at, 1, 10)", "substr(ar.created_at, 1, 10)", ""},
+}
+
+// UsageBy sums the usage of a cluster's agent runs grouped by execution,
+// task, thread, crew or UTC day, most expensive first (days newest first).
+func (d *DB) UsageBy(ctx context.Context, clusterID int64, by string) ([]UsageRollup, error) {
+	g, ok := usageGroupings[by]
+	if !ok {
+		return nil, fmt.Errorf("usage by %q: must be one of execution, task, thread, crew, day", by)
+	}
+	order := "SUM(ar.cost_usd) DESC, key"
+	if by == UsageByDay {
+		order = "key DESC"
+	}
+	rows, err := d.conn.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s AS key, MAX(%s), COUNT(*),
+		       SUM(ar.input_tokens), SUM(ar.output_tokens),
+		       SUM(ar.cache_creation_tokens), SUM(ar.cache_read_tokens),
+		       SUM(ar.cost_usd), SUM(ar.duration_ms)
+		FROM agent_runs ar
+		JOIN executions e ON e.id = ar.execution_id
+		JOIN tasks t ON t.id = e.task_id
+		%s
+		WHERE e.cluster_id = ?
+		GROUP BY key
+		ORDER BY %s
+	`, g.key, g.label, g.join, order), clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("usage by %s: %w", by, err)
+	}
+	defer rows.Close()
+
+	var out []UsageRollup
+	for rows.Next() {
+		var u UsageRollup
+		if err := rows.Scan(&u.Key, &u.Label, &u.Runs,
+			&u.InputTokens, &u.OutputTokens, &u.CacheCreationTokens, &u.CacheReadTokens,
+			&u.CostUSD, &u.DurationMS); err != nil {
+			return nil, fmt.Errorf("scan usage by %s: %w", by, err)
+		}
+		out = append(out, u)
+	}
+	return out, rows.Err()
+}
+
+// UsageTotals sums the usage charged to an execution, to its task and to its
+// cluster since dayStart.
+func (d *DB) UsageTotals(ctx context.Context, execID int64, dayStart time.Time) (UsageTotals, error) {
+	var t UsageTotals
+	scopes := []struct {
+		usage *Usage
+		where string
+		args  []any
+	}{
+		{&t.Execution, "e.id = ?", []any{execID}},
+		{&t.Task, "e.task_id = (SELECT task_id FROM executions WHERE id = ?)", []any{execID}},
+		{&t.Day, "e.cluster_id = (SELECT cluster_id FROM executions WHERE id = ?) AND ar.created_at >= ?",
+			[]any{execID, dayStart.UTC().Format(time.RFC3339)}},
+	}
+	for _, s := range scopes {
+		err := d.conn.QueryRowContext(ctx, `
+			SELECT COALESCE(SUM(ar.input_tokens), 0), COALESCE(SUM(ar.output_tokens), 0),
+			       COALESCE(SUM(ar.cache_creation_tokens), 0), COALESCE(SUM(ar.cache_read_tokens), 0),
+			       COALESCE(SUM(ar.cost_usd), 0), COALESCE(SUM(ar.duration_ms), 0)
+			FROM agent_runs ar
+			JOIN executions e ON e.id = ar.execution_id
+			WHERE `+s.where, s.args...).Scan(
+			&s.usage.InputTokens, &s.usage.OutputTokens,
+			&s.usage.CacheCreationTokens, &s.usage.CacheReadTokens,
+			&s.usage.CostUSD, &s.usage.DurationMS)
+		if err != nil {
+			return t, fmt.Errorf("usage totals for execution %d: %w", execID, err)
+		}
+	}
+	return t, nil
+}
+
+// FailInProgressAgentRuns marks every still running agent run of an
+// execution failed with summary. It returns the number of runs updated.
+func (d *DB) FailInProgressAgentRuns(ctx context.Context, executionID int64, summary string) (int64, error) {
+	res, err := d.conn.ExecContext(ctx,
+		`UPDATE agent_runs SET summary = ?, outcome = ? WHERE execution_id = ? AND outcome = ?`,
+		summary, OutcomeFailed, executionID, OutcomeRunning,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("fail in-progress agent runs: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("fail in-progress agent runs: rows affected: %w", err)
+	}
+	if n > 0 {
+		d.changed(Change{Kind: ChangeAgentRun, ExecutionID: executionID})
+	}
+	return n, nil
+}
+
 func scanAgentRun(s scanner) (*AgentRun, error) {
 	var r AgentRun
 	var createdAt string
 	if err := s.Scan(&r.ID, &r.ExecutionID, &r.AgentType, &r.Role,
-		&r.Prompt, &r.Summary, &r.Outcome, &r.FilesChanged, &createdAt); err != nil {
+		&r.Prompt, &r.Summary, &r.Outcome, &r.FilesChanged, &createdAt,
+		&r.Model, &r.InputTokens, &r.OutputTokens, &r.CacheCreationTokens,
+		&r.CacheReadTokens, &r.CostUSD, &r.DurationMS); err != nil {
 		return nil, fmt.Errorf("scan agent run: %w", err)
 	}
 	var err error
@@ -1087,6 +1343,7 @@ func (d *DB) CreateLesson(ctx context.Context, executionID int64, agentType, les
 	if err != nil {
 		return fmt.Errorf("create lesson: %w", err)
 	}
+	d.changed(Change{Kind: ChangeLesson, ExecutionID: executionID})
 	return nil
 }
 
@@ -1147,6 +1404,520 @@ func collectLessons(rows *sql.Rows) ([]AgentLesson, error) {
 	return out, rows.Err()
 }
 
+// ---------------------------------------------------------------------------
+// Execution Plans
+// ---------------------------------------------------------------------------
+
+// SaveExecutionPlan inserts or replaces the persisted plan of an execution.
+func (d *DB) SaveExecutionPlan(ctx context.Context, executionID int64, briefJSON, planJSON, workersJSON string) error {
+	ts := now()
+	_, err := d.conn.ExecContext(ctx,
+		`INSERT INTO execution_plans (execution_id, brief_json, plan_json, workers_json, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(execution_id) DO UPDATE SET
+		   brief_json = excluded.brief_json,
+		   plan_json = excluded.plan_json,
+		   workers_json = excluded.workers_json,
+		   updated_at = excluded.updated_at`,
+		executionID, briefJSON, planJSON, workersJSON, ts, ts,
+	)
+	if err != nil {
+		return fmt.Errorf("save execution plan: %w", err)
+	}
+	d.changed(Change{Kind: ChangePlan, ExecutionID: executionID})
+	return nil
+}
+
+// UpdateExecutionPlanWorkers replaces the list of spawned workers of a
+// persisted plan.
+func (d *DB) UpdateExecutionPlanWorkers(ctx context.Context, executionID int64, workersJSON string) error {
+	ts := now()
+	res, err := d.conn.ExecContext(ctx,
+		`UPDATE execution_plans SET workers_json = ?, updated_at = ? WHERE execution_id = ?`,
+		workersJSON, ts, executionID,
+	)
+	if err != nil {
+		return fmt.Errorf("update execution plan workers: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update execution plan workers: rows affected: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("update execution plan workers (execution_id=%d): %w", executionID, ErrNotFound)
+	}
+	d.changed(Change{Kind: ChangePlan, ExecutionID: executionID})
+	return nil
+}
+
+// GetExecutionPlan returns the persisted plan of an execution, or nil if the
+// execution never got past the Boss plan phase.
+func (d *DB) GetExecutionPlan(ctx context.Context, executionID int64) (*ExecutionPlan, error) {
+	row := d.conn.QueryRowContext(ctx,
+		`SELECT execution_id, brief_json, plan_json, workers_json, created_at, updated_at
+		 FROM execution_plans WHERE execution_id = ?`, executionID,
+	)
+	var p ExecutionPlan
+	var createdAt, updatedAt string
+	err := row.Scan(&p.ExecutionID, &p.BriefJSON, &p.PlanJSON, &p.WorkersJSON, &createdAt, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get execution plan: %w", err)
+	}
+	p.CreatedAt, err = parseTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+	p.UpdatedAt, err = parseTime(updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ---------------------------------------------------------------------------
+// Boss Plans & Worker Results
+// ---------------------------------------------------------------------------
+
+// Kinds of list rows stored in plan_items and worker_result_items.
+const (
+	planItemValidation    = "validation"
+	planItemEstimatedFile = "estimated_file"
+
+	resultItemFileChanged      = "file_changed"
+	resultItemCommandRun       = "command_run"
+	resultItemValidationResult = "validation_result"
+	resultItemNote             = "note"
+	resultItemBlocker          = "blocker"
+)
+
+// CreateBossPlan inserts a Boss plan with its steps, validation list and
+// estimated files in a single transaction. The returned plan carries the
+// new row IDs.
+func (d *DB) CreateBossPlan(ctx context.Context, p BossPlan) (*BossPlan, error) {
+	ts := now()
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create boss plan: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO boss_plans (execution_id, agent_run_id, created_at) VALUES (?, ?, ?)`,
+		p.ExecutionID, ptrToNullInt64(p.AgentRunID), ts,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create boss plan: %w", err)
+	}
+	p.ID, err = res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("create boss plan: last insert id: %w", err)
+	}
+
+	steps := make([]PlanStep, len(p.Steps))
+	for i, st := range p.Steps {
+		st.PlanID = p.ID
+		st.Position = i
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO plan_steps (plan_id, position, step_key, title, detail, worker_role)
+			 VALUES (?, ?, ?, ?, ?, ?)`,
+			st.PlanID, st.Position, st.StepKey, st.Title, st.Detail, st.WorkerRole,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("create boss plan: step %d: %w", i, err)
+		}
+		st.ID, err = res.LastInsertId()
+		if err != nil {
+			return nil, fmt.Errorf("create boss plan: step %d: last insert id: %w", i, err)
+		}
+		steps[i] = st
+	}
+	p.Steps = steps
+
+	const itemQuery = `INSERT INTO plan_items (plan_id, kind, position, content) VALUES (?, ?, ?, ?)`
+	if err := insertItems(ctx, tx, itemQuery, p.ID, planItemValidation, p.Validation); err != nil {
+		return nil, fmt.Errorf("create boss plan: %w", err)
+	}
+	if err := insertItems(ctx, tx, itemQuery, p.ID, planItemEstimatedFile, p.EstimatedFiles); err != nil {
+		return nil, fmt.Errorf("create boss plan: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("create boss plan: commit: %w", err)
+	}
+	p.CreatedAt, err = parseTime(ts)
+	if err != nil {
+		return nil, fmt.Errorf("create boss plan: parse time: %w", err)
+	}
+	d.changed(Change{Kind: ChangePlan, ID: p.ID, ExecutionID: p.ExecutionID})
+	return &p, nil
+}
+
+// ListBossPlans returns every Boss plan of an execution with its steps and
+// lists, oldest first.
+func (d *DB) ListBossPlans(ctx context.Context, executionID int64) ([]BossPlan, error) {
+	rows, err := d.conn.QueryContext(ctx,
+		`SELECT id, execution_id, agent_run_id, created_at
+		 FROM boss_plans WHERE execution_id = ? ORDER BY id`,
+		executionID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list boss plans: %w", err)
+	}
+	var out []BossPlan
+	for rows.Next() {
+		var p BossPlan
+		var agentRunID sql.NullInt64
+		var createdAt string
+		if err := rows.Scan(&p.ID, &p.ExecutionID, &agentRunID, &createdAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan boss plan: %w", err)
+		}
+		p.AgentRunID = nullableInt64ToPtr(agentRunID)
+		p.CreatedAt, err = parseTime(createdAt)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list boss plans: %w", err)
+	}
+
+	// Child rows are loaded after closing the cursor: the pool has a single
+	// connection, so queries cannot be nested.
+	for i := range out {
+		if out[i].Steps, err = d.listPlanSteps(ctx, out[i].ID); err != nil {
+			return nil, err
+		}
+		items, err := d.listItems(ctx,
+			`SELECT kind, content FROM plan_items WHERE plan_id = ? ORDER BY kind, position`, out[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("list boss plans: %w", err)
+		}
+		out[i].Validation = items[planItemValidation]
+		out[i].EstimatedFiles = items[planItemEstimatedFile]
+	}
+	return out, nil
+}
+
+func (d *DB) listPlanSteps(ctx context.Context, planID int64) ([]PlanStep, error) {
+	rows, err := d.conn.QueryContext(ctx,
+		`SELECT id, plan_id, position, step_key, title, detail, worker_role
+		 FROM plan_steps WHERE plan_id = ? ORDER BY position`,
+		planID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list plan steps: %w", err)
+	}
+	defer rows.Close()
+	var out []PlanStep
+	for rows.Next() {
+		var st PlanStep
+		if err := rows.Scan(&st.ID, &st.PlanID, &st.Position, &st.StepKey, &st.Title, &st.Detail, &st.WorkerRole); err != nil {
+			return nil, fmt.Errorf("scan plan step: %w", err)
+		}
+		out = append(out, st)
+	}
+	return out, rows.Err()
+}
+
+// CreateWorkerResult inserts a worker result with its list fields in a
+// single transaction. The returned result carries the new row ID.
+func (d *DB) CreateWorkerResult(ctx context.Context, wr WorkerResult) (*WorkerResult, error) {
+	ts := now()
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create worker result: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO worker_results (execution_id, agent_run_id, role, outcome, summary, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		wr.ExecutionID, ptrToNullInt64(wr.AgentRunID), wr.Role, wr.Outcome, wr.Summary, ts,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create worker result: %w", err)
+	}
+	wr.ID, err = res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("create worker result: last insert id: %w", err)
+	}
+
+	const itemQuery = `INSERT INTO worker_result_items (worker_result_id, kind, position, content) VALUES (?, ?, ?, ?)`
+	for _, list := range []struct {
+		kind   string
+		values []string
+	}{
+		{resultItemFileChanged, wr.FilesChanged},
+		{resultItemCommandRun, wr.CommandsRun},
+		{resultItemValidationResult, wr.ValidationResults},
+		{resultItemNote, wr.Notes},
+		{resultItemBlocker, wr.Blockers},
+	} {
+		if err := insertItems(ctx, tx, itemQuery, wr.ID, list.kind, list.values); err != nil {
+			return nil, fmt.Errorf("create worker result: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("create worker result: commit: %w", err)
+	}
+	wr.CreatedAt, err = parseTime(ts)
+	if err != nil {
+		return nil, fmt.Errorf("create worker result: parse time: %w", err)
+	}
+	d.changed(Change{Kind: ChangeResult, ID: wr.ID, ExecutionID: wr.ExecutionID})
+	return &wr, nil
+}
+
+// ListWorkerResults returns every worker result of an execution with its
+// list fields, oldest first.
+func (d *DB) ListWorkerResults(ctx context.Context, executionID int64) ([]WorkerResult, error) {
+	rows, err := d.conn.QueryContext(ctx,
+		`SELECT id, execution_id, agent_run_id, role, outcome, summary, created_at
+		 FROM worker_results WHERE execution_id = ? ORDER BY id`,
+		executionID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list worker results: %w", err)
+	}
+	var out []WorkerResult
+	for rows.Next() {
+		wr, err := scanWorkerResult(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		out = append(out, *wr)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list worker results: %w", err)
+	}
+	for i := range out {
+		if err := d.loadWorkerResultItems(ctx, &out[i]); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// GetWorkerResultByAgentRun returns the worker result produced by an agent
+// run, or nil if that run did not record one.
+func (d *DB) GetWorkerResultByAgentRun(ctx context.Context, agentRunID int64) (*WorkerResult, error) {
+	row := d.conn.QueryRowContext(ctx,
+		`SELECT id, execution_id, agent_run_id, role, outcome, summary, created_at
+		 FROM worker_results WHERE agent_run_id = ? ORDER BY id DESC LIMIT 1`,
+		agentRunID,
+	)
+	wr, err := scanWorkerResult(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := d.loadWorkerResultItems(ctx, wr); err != nil {
+		return nil, err
+	}
+	return wr, nil
+}
+
+func scanWorkerResult(s scanner) (*WorkerResult, error) {
+	var wr WorkerResult
+	var agentRunID sql.NullInt64
+	var createdAt string
+	if err := s.Scan(&wr.ID, &wr.ExecutionID, &agentRunID, &wr.Role, &wr.Outcome, &wr.Summary, &createdAt); err != nil {
+		return nil, fmt.Errorf("scan worker result: %w", err)
+	}
+	wr.AgentRunID = nullableInt64ToPtr(agentRunID)
+	var err error
+	wr.CreatedAt, err = parseTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+	return &wr, nil
+}
+
+func (d *DB) loadWorkerResultItems(ctx context.Context, wr *WorkerResult) error {
+	items, err := d.listItems(ctx,
+		`SELECT kind, content FROM worker_result_items WHERE worker_result_id = ? ORDER BY kind, position`, wr.ID)
+	if err != nil {
+		return fmt.Errorf("load worker result items: %w", err)
+	}
+	wr.FilesChanged = items[resultItemFileChanged]
+	wr.CommandsRun = items[resultItemCommandRun]
+	wr.ValidationResults = items[resultItemValidationResult]
+	wr.Notes = items[resultItemNote]
+	wr.Blockers = items[resultItemBlocker]
+	return nil
+}
+
+// insertItems inserts one list row per value, preserving order. query takes
+// the parent ID, kind, position and content.
+func insertItems(ctx context.Context, tx *sql.Tx, query string, parentID int64, kind string, values []string) error {
+	for i, v := range values {
+		if _, err := tx.ExecContext(ctx, query, parentID, kind, i, v); err != nil {
+			return fmt.Errorf("insert %s %d: %w", kind, i, err)
+		}
+	}
+	return nil
+}
+
+// listItems runs a (kind, content) query ordered by kind and position and
+// groups the contents by kind.
+func (d *DB) listItems(ctx context.Context, query string, parentID int64) (map[string][]string, error) {
+	rows, err := d.conn.QueryContext(ctx, query, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := make(map[string][]string)
+	for rows.Next() {
+		var kind, content string
+		if err := rows.Scan(&kind, &content); err != nil {
+			return nil, err
+		}
+		items[kind] = append(items[kind], content)
+	}
+	return items, rows.Err()
+}
+
+// ---------------------------------------------------------------------------
+// Execution Questions
+// ---------------------------------------------------------------------------
+
+// CreateQuestion records an open question raised during an execution.
+func (d *DB) CreateQuestion(ctx context.Context, executionID int64, kind, agentRole, question string) (*ExecutionQuestion, error) {
+	ts := now()
+	res, err := d.conn.ExecContext(ctx,
+		`INSERT INTO execution_questions (execution_id, kind, agent_role, question, status, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		executionID, kind, agentRole, question, QuestionOpen, ts,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create question: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("create question: last insert id: %w", err)
+	}
+	t, err := parseTime(ts)
+	if err != nil {
+		return nil, err
+	}
+	d.changed(Change{Kind: ChangeQuestion, ID: id, ExecutionID: executionID})
+	return &ExecutionQuestion{
+		ID:          id,
+		ExecutionID: executionID,
+		Kind:        kind,
+		AgentRole:   agentRole,
+		Question:    question,
+		Status:      QuestionOpen,
+		CreatedAt:   t,
+	}, nil
+}
+
+// AnswerQuestion stores the user's answer and marks the question answered.
+// Only an open question can be answered; one that was already answered or
+// closed reports ErrNotFound.
+func (d *DB) AnswerQuestion(ctx context.Context, id int64, answer string) error {
+	ts := now()
+	var executionID int64
+	err := d.conn.QueryRowContext(ctx,
+		`UPDATE execution_questions SET answer = ?, status = ?, answered_at = ?
+		 WHERE id = ? AND status = ? RETURNING execution_id`,
+		answer, QuestionAnswered, ts, id, QuestionOpen,
+	).Scan(&executionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("answer question (id=%d): no open question: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("answer question: %w", err)
+	}
+	d.changed(Change{Kind: ChangeQuestion, ID: id, ExecutionID: executionID})
+	return nil
+}
+
+// CloseOpenQuestions closes every open question of an execution, for when
+// the execution stops before they are answered. It returns the number of
+// questions closed.
+func (d *DB) CloseOpenQuestions(ctx context.Context, executionID int64) (int64, error) {
+	res, err := d.conn.ExecContext(ctx,
+		`UPDATE execution_questions SET status = ? WHERE execution_id = ? AND status = ?`,
+		QuestionClosed, executionID, QuestionOpen,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("close open questions: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("close open questions: rows affected: %w", err)
+	}
+	if n > 0 {
+		d.changed(Change{Kind: ChangeQuestion, ExecutionID: executionID})
+	}
+	return n, nil
+}
+
+// GetQuestion returns a question by ID.
+func (d *DB) GetQuestion(ctx context.Context, id int64) (*ExecutionQuestion, error) {
+	row := d.conn.QueryRowContext(ctx,
+		`SELECT id, execution_id, kind, agent_role, question, answer, status, created_at, answered_at
+		 FROM execution_questions WHERE id = ?`, id,
+	)
+	return scanQuestion(row)
+}
+
+// ListQuestions returns all questions for an execution, oldest first.
+func (d *DB) ListQuestions(ctx context.Context, executionID int64) ([]ExecutionQuestion, error) {
+	rows, err := d.conn.QueryContext(ctx,
+		`SELECT id, execution_id, kind, agent_role, question, answer, status, created_at, answered_at
+		 FROM execution_questions WHERE execution_id = ? ORDER BY id`,
+		executionID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list questions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []ExecutionQuestion
+	for rows.Next() {
+		q, err := scanQuestion(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *q)
+	}
+	return out, rows.Err()
+}
+
+func scanQuestion(s scanner) (*ExecutionQuestion, error) {
+	var q ExecutionQuestion
+	var createdAt string
+	var answeredAt sql.NullString
+	if err := s.Scan(&q.ID, &q.ExecutionID, &q.Kind, &q.AgentRole, &q.Question,
+		&q.Answer, &q.Status, &createdAt, &answeredAt); err != nil {
+		return nil, fmt.Errorf("scan question: %w", err)
+	}
+	var err error
+	q.CreatedAt, err = parseTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+	q.AnsweredAt, err = parseNullableTime(answeredAt)
+	if err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
 // ---------------------------------------------------------------------------
 // Context Search (for Commander reuse)
 // ---------------------------------------------------------------------------
@@ -1154,6 +1925,7 @@ func collectLessons(rows *sql.Rows) ([]AgentLesson, error) {
 // SearchRelevantRuns finds agent runs relevant to a thread by matching thread
 // membership and keyword overlap on task prompts and agent summaries.
 // Keywords are matched with LIKE and results are ranked by match count.
+// Runs that have not finished are skipped.
 func (d *DB) SearchRelevantRuns(ctx context.Context, clusterID int64, threadID int64, keywords []string, limit int) ([]AgentRun, error) {
 	if len(keywords) == 0 {
 		return nil, nil
@@ -1186,6 +1958,7 @@ func (d *DB) SearchRelevantRuns(ctx context.Context, clusterID int64, threadID i
 		JOIN executions e ON e.id = ar.execution_id
 		JOIN tasks t ON t.id = e.task_id
 		WHERE e.cluster_id = ?
+		  AND ar.outcome != 'running'
 		  AND (t.thread_id = ? OR (%s) > 0)
 		ORDER BY relevance DESC, ar.created_at DESC
 		LIMIT ?
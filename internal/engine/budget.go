@@ -0,0 +1,178 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"bore-tui/internal/config"
+	"bore-tui/internal/db"
+)
+
+// budgetCeiling is one configured ceiling and what has been charged to its
+// scope so far.
+type budgetCeiling struct {
+	scope  string // "Execution", "Task" or "Daily"
+	tokens bool   // a token ceiling rather than a spend ceiling
+	spent  float64
+	limit  float64
+}
+
+// name describes the ceiling, e.g. "Daily spend" or "Task tokens".
+func (c budgetCeiling) name() string {
+	if c.tokens {
+		return c.scope + " tokens"
+	}
+	return c.scope + " spend"
+}
+
+// format renders an amount in the ceiling's unit, with cents to spare for
+// ceilings under a dollar.
+func (c budgetCeiling) format(v float64) string {
+	switch {
+	case c.tokens:
+		return fmt.Sprintf("%.0f tokens", v)
+	case c.limit < 1:
+		return fmt.Sprintf("$%.4f", v)
+	}
+	return fmt.Sprintf("$%.2f", v)
+}
+
+// budgetCeilings pairs every ceiling set in b with the usage charged to its
+// scope. Token ceilings count cached tokens too.
+func budgetCeilings(b config.BudgetConfig, t db.UsageTotals) []budgetCeiling {
+	all := []budgetCeiling{
+		{"Execution", false, t.Execution.CostUSD, b.MaxExecutionUSD},
+		{"Execution", true, float64(t.Execution.Tokens()), float64(b.MaxExecutionTokens)},
+		{"Task", false, t.Task.CostUSD, b.MaxTaskUSD},
+		{"Task", true, float64(t.Task.Tokens()), float64(b.MaxTaskTokens)},
+		{"Daily", false, t.Day.CostUSD, b.MaxDailyUSD},
+		{"Daily", true, float64(t.Day.Tokens()), float64(b.MaxDailyTokens)},
+	}
+	var set []budgetCeiling
+	for _, c := range all {
+		if c.limit > 0 {
+			set = append(set, c)
+		}
+	}
+	return set
+}
+
+// checkBudget compares what the execution, its task and its cluster have
+// spent today against agents.budget. Crossing a warn_at threshold records a
+// budget_warning event; reaching a ceiling records budget_exceeded and marks
+// the run over budget, which withinBudget then enforces. Under BudgetAbort
+// the run is stopped at once.
+func (e *Engine) checkBudget(r *run) {
+	cfg := e.a.Config()
+	if cfg == nil || !cfg.Agents.Budget.Limited() {
+		return
+	}
+	b := cfg.Agents.Budget
+	bg := context.Background()
+
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	totals, err := e.a.DB().UsageTotals(bg, r.execID, midnight)
+	if err != nil {
+		e.emit(r, fmt.Sprintf("Warning: could not check budget: %v", err))
+		return
+	}
+
+	for _, c := range budgetCeilings(b, totals) {
+		frac := c.spent / c.limit
+		if frac >= 1 {
+			e.exceedBudget(r, b.OnExceeded, fmt.Sprintf("%s of %s reached its ceiling of %s",
+				c.name(), c.format(c.spent), c.format(c.limit)))
+			continue
+		}
+
+		// Warn once per ceiling for the highest threshold crossed.
+		var crossed float64
+		r.mu.Lock()
+		for _, w := range b.WarnAt {
+			key := fmt.Sprintf("%s/%g", c.name(), w)
+			if frac >= w && !r.budgetWarned[key] {
+				r.budgetWarned[key] = true
+				crossed = max(crossed, w)
+			}
+		}
+		r.mu.Unlock()
+		if crossed == 0 {
+			continue
+		}
+		msg := fmt.Sprintf("Budget warning: %s is at %.0f%% of its ceiling (%s of %s)",
+			c.name(), frac*100, c.format(c.spent), c.format(c.limit))
+		_ = e.a.DB().CreateEvent(bg, r.execID, db.LevelWarn, "budget_warning", msg)
+		e.emit(r, msg+".")
+	}
+}
+
+// exceedBudget records the first ceiling the run exceeds and applies the
+// policy to it.
+func (e *Engine) exceedBudget(r *run, policy, reason string) {
+	r.mu.Lock()
+	first := r.overBudget == ""
+	if first {
+		r.overBudget = reason
+	}
+	r.mu.Unlock()
+	if !first {
+		return
+	}
+
+	reason = "Budget exceeded: " + reason
+	_ = e.a.DB().CreateEvent(context.Background(), r.execID, db.LevelError, "budget_exceeded", reason)
+	if policy == config.BudgetAbort {
+		e.emit(r, reason+". Aborting execution.")
+		r.stop(db.StatusCancelled, "Execution aborted: "+reason)
+		return
+	}
+	e.emit(r, reason+".")
+}
+
+// overBudgetNow reports whether the run has exceeded a ceiling the user
+// has not chosen to carry on past. A nil run has no ceilings.
+func (r *run) overBudgetNow() bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.overBudget != "" && !r.budgetWaived
+}
+
+// withinBudget reports whether the execution may start another worker or
+// Boss review. Once a ceiling is exceeded it returns false, except that a
+// task in alert_with_issues mode first pauses and asks the user, once,
+// whether to carry on; answering "continue" lifts the ceilings for the rest
+// of the execution. Other callers wait while the question is open.
+func (e *Engine) withinBudget(ctx context.Context, r *run, task *db.Task) bool {
+	r.budgetMu.Lock()
+	defer r.budgetMu.Unlock()
+
+	r.mu.Lock()
+	reason, waived := r.overBudget, r.budgetWaived
+	r.mu.Unlock()
+	if reason == "" || waived {
+		return true
+	}
+	if task.Mode != db.ModeAlertWithIssues || r.budgetAsked {
+		return false
+	}
+	r.budgetAsked = true
+
+	question := fmt.Sprintf("Budget exceeded: %s.\nReply \"continue\" to carry on past the budget for the rest of this execution; any other reply starts no further workers and moves to the Boss summary.", reason)
+	answer, err := e.ask(ctx, r, db.QuestionBudget, "budget", question)
+	if err != nil || !strings.EqualFold(strings.TrimSpace(answer), "continue") {
+		return false
+	}
+	r.mu.Lock()
+	r.budgetWaived = true
+	r.mu.Unlock()
+	_ = e.a.DB().CreateEvent(context.Background(), r.execID, db.LevelWarn, "budget_override",
+		"User chose to continue past the budget: "+reason)
+	e.emit(r, "Continuing past the budget at your request.")
+	return true
+}
@@ -0,0 +1,167 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"bore-tui/internal/agents"
+	"bore-tui/internal/config"
+	"bore-tui/internal/db"
+	"bore-tui/internal/process"
+)
+
+// The Commander review turns a task into an approved execution brief:
+// Clarify → ProposeOptions → DraftBrief → ApproveBrief. Each step records
+// its input and the Commander's output in task_reviews, so every front-end
+// leaves the same trail and a review can be picked up where it stopped.
+
+// Clarify asks the Commander which questions it needs answered about task
+// and records them. A pending task moves to review.
+func (e *Engine) Clarify(ctx context.Context, task *db.Task) (*agents.ClarificationsResponse, error) {
+	parsed, err := e.askCommander(ctx, "clarifications", agents.BuildClarificationPrompt(task.Prompt))
+	if err != nil {
+		return nil, fmt.Errorf("engine: clarify: %w", err)
+	}
+	resp, ok := parsed.(agents.ClarificationsResponse)
+	if !ok {
+		return nil, fmt.Errorf("engine: clarify: unexpected response type for clarifications: %T", parsed)
+	}
+	if err := e.recordReview(ctx, task.ID, db.PhaseClarification, resp); err != nil {
+		return nil, fmt.Errorf("engine: clarify: %w", err)
+	}
+	if task.Status == db.StatusPending {
+		if err := e.a.DB().UpdateTaskStatus(ctx, task.ID, db.StatusReview); err != nil {
+			return nil, fmt.Errorf("engine: clarify: update task status: %w", err)
+		}
+		task.Status = db.StatusReview
+	}
+	return &resp, nil
+}
+
+// ProposeOptions records the user's answers, keyed by question ID, and asks
+// the Commander for execution options.
+func (e *Engine) ProposeOptions(ctx context.Context, task *db.Task, answers map[string]string) (*agents.OptionsResponse, error) {
+	if err := e.recordReview(ctx, task.ID, db.PhaseAnswers, answers); err != nil {
+		return nil, fmt.Errorf("engine: options: %w", err)
+	}
+	parsed, err := e.askCommander(ctx, "options", agents.BuildOptionsPrompt(task.Prompt, answers))
+	if err != nil {
+		return nil, fmt.Errorf("engine: options: %w", err)
+	}
+	resp, ok := parsed.(agents.OptionsResponse)
+	if !ok {
+		return nil, fmt.Errorf("engine: options: unexpected response type for options: %T", parsed)
+	}
+	if err := e.recordReview(ctx, task.ID, db.PhaseOptions, resp); err != nil {
+		return nil, fmt.Errorf("engine: options: %w", err)
+	}
+	return &resp, nil
+}
+
+// DraftBrief records the chosen option and base branch and asks the
+// Commander for the execution brief.
+func (e *Engine) DraftBrief(ctx context.Context, task *db.Task, optionID, baseBranch string) (*agents.ExecutionBrief, error) {
+	if err := e.recordReview(ctx, task.ID, db.PhaseSelection, optionID); err != nil {
+		return nil, fmt.Errorf("engine: brief: %w", err)
+	}
+	if err := e.recordReview(ctx, task.ID, db.PhaseBaseBranch, baseBranch); err != nil {
+		return nil, fmt.Errorf("engine: brief: %w", err)
+	}
+	parsed, err := e.askCommander(ctx, "brief", agents.BuildExecutionBriefPrompt(task.Prompt, optionID, baseBranch))
+	if err != nil {
+		return nil, fmt.Errorf("engine: brief: %w", err)
+	}
+	resp, ok := parsed.(agents.ExecutionBrief)
+	if !ok {
+		return nil, fmt.Errorf("engine: brief: unexpected response type for brief: %T", parsed)
+	}
+	if err := e.recordReview(ctx, task.ID, db.PhaseBrief, resp); err != nil {
+		return nil, fmt.Errorf("engine: brief: %w", err)
+	}
+	return &resp, nil
+}
+
+// ApproveBrief records the approved brief and prepares an execution for it
+// off the brief's base branch, assigned to the crew the brief names. The
+// caller starts it with StartWithBrief.
+func (e *Engine) ApproveBrief(ctx context.Context, task *db.Task, brief agents.ExecutionBrief) (*db.Execution, error) {
+	if e.a.DB() == nil {
+		return nil, fmt.Errorf("engine: approve: no cluster open")
+	}
+	if err := e.recordReview(ctx, task.ID, db.PhaseApproval, brief); err != nil {
+		return nil, fmt.Errorf("engine: approve: %w", err)
+	}
+	crewID, err := e.briefCrew(ctx, task.ClusterID, brief.Crew)
+	if err != nil {
+		return nil, fmt.Errorf("engine: approve: %w", err)
+	}
+	return e.Prepare(ctx, task, brief.BaseBranch, crewID)
+}
+
+// briefCrew looks up the crew named in a brief among the cluster's crews.
+// It returns nil for "none", an empty name or a name no crew has.
+func (e *Engine) briefCrew(ctx context.Context, clusterID int64, name string) (*int64, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "none") {
+		return nil, nil
+	}
+	crews, err := e.a.DB().ListCrews(ctx, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("list crews: %w", err)
+	}
+	for _, c := range crews {
+		if strings.EqualFold(c.Name, name) {
+			id := c.ID
+			return &id, nil
+		}
+	}
+	return nil, nil
+}
+
+// askCommander runs one Commander prompt with the cluster's context and
+// parses its JSON response. phase names the prompt in errors.
+func (e *Engine) askCommander(ctx context.Context, phase, userPrompt string) (any, error) {
+	cluster := e.a.Cluster()
+	if cluster == nil || e.a.DB() == nil {
+		return nil, fmt.Errorf("no cluster open")
+	}
+	cmdCtx, err := agents.LoadCommanderContext(ctx, e.a.DB(), cluster.ID)
+	if err != nil {
+		return nil, fmt.Errorf("build commander context: %w", err)
+	}
+	fullPrompt := agents.BuildCommanderSystemPrompt(cmdCtx) + "\n\n" + userPrompt
+
+	// Rate limits, overloads and network errors are retried, and a response
+	// that is missing or breaks its schema is sent back for correction, as
+	// for the Boss and workers.
+	result, parsed, parseErr := e.runAgent(ctx, nil, nil, config.RoleCommander, cluster.RepoPath, fullPrompt, process.RunOptions{Permissions: process.ReadOnly()})
+	if result.Err != nil {
+		return nil, fmt.Errorf("commander %s: %w", phase, result.Err)
+	}
+	if result.JSONBlock == "" {
+		return nil, fmt.Errorf("no JSON response from commander (%s)", phase)
+	}
+	if parseErr != nil {
+		return nil, fmt.Errorf("parse %s: %w", phase, parseErr)
+	}
+	return parsed, nil
+}
+
+// recordReview stores one review phase. Strings are stored as is, anything
+// else as JSON.
+func (e *Engine) recordReview(ctx context.Context, taskID int64, phase string, content any) error {
+	text, ok := content.(string)
+	if !ok {
+		data, err := json.Marshal(content)
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", phase, err)
+		}
+		text = string(data)
+	}
+	if _, err := e.a.DB().CreateTaskReview(ctx, taskID, phase, text); err != nil {
+		return err
+	}
+	return nil
+}
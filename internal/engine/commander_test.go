@@ -0,0 +1,75 @@
+//go:build unix
+
+package engine_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"bore-tui/internal/config"
+	"bore-tui/internal/db"
+	"bore-tui/internal/engine"
+)
+
+// throttledCommander is rate limited until the file named by $THROTTLED
+// exists, which it creates on its first run, and then asks one question.
+const throttledCommander = `#!/bin/sh
+cat > /dev/null
+if [ ! -e "$THROTTLED" ]; then
+	touch "$THROTTLED"
+	echo "429 Too Many Requests: rate limit exceeded" >&2
+	exit 1
+fi
+echo '{"type":"clarifications","questions":[{"id":"q1","question":"Which greeting?","why":"It sets the file contents"}]}'
+`
+
+// TestClarifyRetriesRateLimits checks that a Commander run that is rate
+// limited is retried under the rate limit policy.
+func TestClarifyRetriesRateLimits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	t.Setenv("HOME", t.TempDir())
+
+	root := t.TempDir()
+	script := filepath.Join(root, "commander.sh")
+	if err := os.WriteFile(script, []byte(throttledCommander), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	throttled := filepath.Join(root, "throttled")
+	t.Setenv("THROTTLED", throttled)
+
+	a := openCluster(t, filepath.Join(root, "repo"), func(ac *config.AgentsConfig) {
+		ac.Backends = map[string]config.BackendConfig{
+			"scripted": {Type: config.BackendTypeCommand, Command: []string{"/bin/sh", script}},
+		}
+		ac.RoleBackends = map[string]string{config.RoleCommander: "scripted"}
+		ac.Retry.RateLimit = config.RetryPolicy{Retries: 1, BaseDelaySec: 0.01}
+	})
+
+	ctx := context.Background()
+	d := a.DB()
+	clusterID := a.Cluster().ID
+	thread, err := d.CreateThread(ctx, clusterID, "Greetings", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := d.CreateTask(ctx, clusterID, thread.ID, "Add a greeting", "Add greeting.txt.", db.ComplexityBasic, db.ModeJustGetItDone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := engine.New(a).Clarify(ctx, task)
+	if err != nil {
+		t.Fatalf("clarify: %v", err)
+	}
+	if _, err := os.Stat(throttled); err != nil {
+		t.Errorf("the first Commander run did not happen: %v", err)
+	}
+	if len(resp.Questions) != 1 || resp.Questions[0].ID != "q1" {
+		t.Errorf("questions = %+v, want q1", resp.Questions)
+	}
+}
@@ -0,0 +1,507 @@
+// Package engine runs BORE executions (Boss plan → workers → Boss summary)
+// independently of any front-end. The TUI, the web server and future
+// headless callers all drive executions through a shared Engine and observe
+// progress through its event stream.
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"bore-tui/internal/agents"
+	"bore-tui/internal/app"
+	"bore-tui/internal/db"
+)
+
+// maxOutputLines bounds the per-execution output buffer kept for snapshots.
+const maxOutputLines = 500
+
+// subscriberBuffer is the channel capacity for each event subscriber.
+const subscriberBuffer = 256
+
+// Engine owns all in-flight executions for an App.
+type Engine struct {
+	a *app.App
+
+	mu   sync.Mutex
+	runs map[int64]*run
+	subs map[chan Event]struct{}
+}
+
+// run tracks the state of a single in-flight execution.
+type run struct {
+	execID int64
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu       sync.Mutex
+	paused   bool
+	unpaused chan struct{} // closed while not paused
+	step     string
+	total    int
+	finished int
+	output   []string
+	active   map[int64]bool  // agent_runs rows currently in flight
+	stopped  string          // reason given to stop; empty while not stopped
+	stopAs   string          // status to record once stopped
+	offside  map[string]bool // files already reported outside the crew's ownership paths
+
+	// Budget state. overBudget is the first ceiling the execution exceeded,
+	// empty while within budget; budgetWaived is set once the user chose to
+	// carry on past it. budgetWarned records the warn_at thresholds already
+	// reported. budgetMu serializes withinBudget and guards budgetAsked.
+	overBudget   string
+	budgetWaived bool
+	budgetWarned map[string]bool
+	budgetMu     sync.Mutex
+	budgetAsked  bool
+
+	// Escalation state for alert_with_issues mode. questions maps an open
+	// question ID to the channel its answer is delivered on; guidance
+	// accumulates answered questions for later Boss calls.
+	questions map[int64]chan string
+	prevStep  string
+	guidance  []string
+
+	// planMu guards workers and serializes their persistence.
+	planMu  sync.Mutex
+	workers []plannedWorker
+}
+
+// New creates an Engine bound to the given App. The App does not need to
+// have a cluster open yet; the engine resolves DB, runner and scheduler at
+// the time an execution starts.
+func New(a *app.App) *Engine {
+	return &Engine{
+		a:    a,
+		runs: make(map[int64]*run),
+		subs: make(map[chan Event]struct{}),
+	}
+}
+
+// Subscribe registers a new event listener. The returned function
+// unsubscribes and closes the channel. Delivery is non-blocking: a
+// subscriber that falls more than subscriberBuffer events behind misses
+// events rather than stalling executions.
+func (e *Engine) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+	e.mu.Lock()
+	e.subs[ch] = struct{}{}
+	e.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			e.mu.Lock()
+			delete(e.subs, ch)
+			e.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// publish delivers ev to all subscribers without blocking.
+func (e *Engine) publish(ev Event) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for ch := range e.subs {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// Start begins running a pending execution in the background. The brief is
+// derived from the execution and task records.
+func (e *Engine) Start(execID int64) error {
+	return e.start(execID, nil)
+}
+
+// StartWithBrief is like Start but hands the Commander's approved brief to
+// the Boss instead of a minimal one derived from the task.
+func (e *Engine) StartWithBrief(execID int64, brief agents.ExecutionBrief) error {
+	return e.start(execID, &brief)
+}
+
+func (e *Engine) start(execID int64, brief *agents.ExecutionBrief) error {
+	if e.a.DB() == nil {
+		return fmt.Errorf("engine: start: no cluster open")
+	}
+
+	exec, err := e.a.DB().GetExecution(context.Background(), execID)
+	if err != nil {
+		return fmt.Errorf("engine: start: load execution %d: %w", execID, err)
+	}
+	if exec == nil {
+		return fmt.Errorf("engine: start: execution %d not found", execID)
+	}
+	if exec.Status != db.StatusPending {
+		return fmt.Errorf("engine: start: execution %d is %s, not pending", execID, exec.Status)
+	}
+	return e.launch(exec, func(ctx context.Context, r *run) string {
+		return e.execute(ctx, r, exec, brief, nil)
+	})
+}
+
+// launch registers a run for exec and drives it with body in the background.
+// body returns the final execution status.
+func (e *Engine) launch(exec *db.Execution, body func(ctx context.Context, r *run) string) error {
+	execID := exec.ID
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &run{
+		execID:    execID,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+		unpaused:  make(chan struct{}),
+		step:      StepStarting,
+		questions: make(map[int64]chan string),
+		active:    make(map[int64]bool),
+		offside:   make(map[string]bool),
+
+		budgetWarned: make(map[string]bool),
+	}
+	close(r.unpaused)
+
+	e.mu.Lock()
+	if _, ok := e.runs[execID]; ok {
+		e.mu.Unlock()
+		cancel()
+		return fmt.Errorf("engine: execution %d is already running", execID)
+	}
+	e.runs[execID] = r
+	e.mu.Unlock()
+
+	go func() {
+		defer close(r.done)
+		status := body(ctx, r)
+
+		e.mu.Lock()
+		delete(e.runs, execID)
+		e.mu.Unlock()
+		cancel()
+
+		e.publish(Event{ExecutionID: execID, Kind: EventFinished, Step: StepDone, Status: status})
+	}()
+
+	return nil
+}
+
+// Cancel stops an in-flight execution. Running agents are killed along with
+// their whole process group, in-flight agent runs are marked failed and the
+// execution is marked cancelled. The worktree is left intact for review and
+// the execution can be resumed.
+func (e *Engine) Cancel(execID int64) error {
+	r := e.lookup(execID)
+	if r == nil {
+		return fmt.Errorf("engine: cancel: execution %d is not running", execID)
+	}
+	r.stop(db.StatusCancelled, "Execution cancelled by user")
+	return nil
+}
+
+// Shutdown cancels every in-flight execution and waits until they have
+// recorded their interrupted state, or until ctx is done. Front-ends call it
+// on exit so no Claude CLI processes outlive bore-tui.
+func (e *Engine) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	runs := make([]*run, 0, len(e.runs))
+	for _, r := range e.runs {
+		runs = append(runs, r)
+	}
+	e.mu.Unlock()
+
+	for _, r := range runs {
+		r.stop(db.StatusInterrupted, "Execution interrupted: bore-tui is shutting down")
+	}
+	for _, r := range runs {
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+			return fmt.Errorf("engine: shutdown: %w", ctx.Err())
+		}
+	}
+	return nil
+}
+
+// Pause stops an execution from launching further agents. Agents that are
+// already running finish normally; the next Boss or worker call waits until
+// Unpause is called.
+func (e *Engine) Pause(execID int64) error {
+	r := e.lookup(execID)
+	if r == nil {
+		return fmt.Errorf("engine: pause: execution %d is not running", execID)
+	}
+	r.mu.Lock()
+	if r.paused {
+		r.mu.Unlock()
+		return nil
+	}
+	r.paused = true
+	r.unpaused = make(chan struct{})
+	r.mu.Unlock()
+
+	e.emit(r, "Execution paused. Running agents will finish; no new agents will start.")
+	e.publish(Event{ExecutionID: execID, Kind: EventPaused})
+	return nil
+}
+
+// Unpause lets a paused execution continue launching agents.
+func (e *Engine) Unpause(execID int64) error {
+	r := e.lookup(execID)
+	if r == nil {
+		return fmt.Errorf("engine: unpause: execution %d is not running", execID)
+	}
+	r.mu.Lock()
+	if !r.paused {
+		r.mu.Unlock()
+		return nil
+	}
+	r.paused = false
+	close(r.unpaused)
+	r.mu.Unlock()
+
+	e.emit(r, "Execution unpaused.")
+	e.publish(Event{ExecutionID: execID, Kind: EventUnpaused})
+	return nil
+}
+
+// Answer delivers the user's answer to an open question and lets the
+// execution continue. For a worker blocker, an empty answer accepts the
+// worker's result as is; a non-empty answer re-runs the worker with the
+// answer as guidance and is passed on to the Boss for the rest of the
+// execution.
+func (e *Engine) Answer(questionID int64, answer string) error {
+	if e.a.DB() == nil {
+		return fmt.Errorf("engine: answer: no cluster open")
+	}
+	q, err := e.a.DB().GetQuestion(context.Background(), questionID)
+	if err != nil {
+		return fmt.Errorf("engine: answer: load question %d: %w", questionID, err)
+	}
+	if q.Status != db.QuestionOpen {
+		return fmt.Errorf("engine: answer: question %d is no longer open", questionID)
+	}
+	r := e.lookup(q.ExecutionID)
+	if r == nil {
+		return fmt.Errorf("engine: answer: execution %d is not running", q.ExecutionID)
+	}
+
+	r.mu.Lock()
+	ch, ok := r.questions[questionID]
+	if ok {
+		delete(r.questions, questionID)
+		if answer != "" && q.Kind == db.QuestionBlocker {
+			r.guidance = append(r.guidance, formatGuidance(q.AgentRole, q.Question, answer))
+		}
+	}
+	r.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("engine: answer: question %d is not awaiting an answer", questionID)
+	}
+
+	if err := e.a.DB().AnswerQuestion(context.Background(), questionID, answer); err != nil {
+		// The worker is unblocked regardless; the answer is still applied.
+		e.emit(r, fmt.Sprintf("Warning: could not save answer: %v", err))
+	}
+	ch <- answer
+	e.publish(Event{ExecutionID: q.ExecutionID, Kind: EventAnswered, QuestionID: questionID})
+	return nil
+}
+
+// Running reports whether the engine currently owns the given execution.
+func (e *Engine) Running(execID int64) bool {
+	return e.lookup(execID) != nil
+}
+
+// Snapshot returns the current state of an in-flight execution. The second
+// return value is false if the engine is not running that execution.
+func (e *Engine) Snapshot(execID int64) (Snapshot, bool) {
+	r := e.lookup(execID)
+	if r == nil {
+		return Snapshot{ExecutionID: execID}, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	active := make([]int64, 0, len(r.active))
+	for id := range r.active {
+		active = append(active, id)
+	}
+	return Snapshot{
+		ExecutionID:  execID,
+		Running:      true,
+		Paused:       r.paused,
+		Step:         r.step,
+		WorkersTotal: r.total,
+		WorkersDone:  r.finished,
+		Questions:    len(r.questions),
+		ActiveRuns:   active,
+		Output:       append([]string(nil), r.output...),
+	}, true
+}
+
+// Wait blocks until the given execution is no longer running or ctx is done.
+func (e *Engine) Wait(ctx context.Context, execID int64) error {
+	r := e.lookup(execID)
+	if r == nil {
+		return nil
+	}
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *Engine) lookup(execID int64) *run {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.runs[execID]
+}
+
+// emit appends a progress line to the run's buffer and publishes it.
+func (e *Engine) emit(r *run, line string) {
+	r.mu.Lock()
+	r.appendOutput(line)
+	r.mu.Unlock()
+	e.publish(Event{ExecutionID: r.execID, Kind: EventOutput, Message: line})
+}
+
+// appendOutput adds line to the bounded output buffer. r.mu must be held.
+func (r *run) appendOutput(line string) {
+	r.output = append(r.output, line)
+	if len(r.output) > maxOutputLines {
+		r.output = r.output[len(r.output)-maxOutputLines:]
+	}
+}
+
+// setStep records the run's current phase and publishes it.
+func (e *Engine) setStep(r *run, step string) {
+	r.mu.Lock()
+	r.step = step
+	r.mu.Unlock()
+	e.publish(Event{ExecutionID: r.execID, Kind: EventStep, Step: step})
+}
+
+// ask records a question of the given kind (one of the db question kinds)
+// from role and blocks until it is answered via Engine.Answer or
+// ctx is done. While any question is open the run reports StepAwaitingUser.
+func (e *Engine) ask(ctx context.Context, r *run, kind, role, question string) (string, error) {
+	bg := context.Background()
+	q, err := e.a.DB().CreateQuestion(bg, r.execID, kind, role, question)
+	if err != nil {
+		return "", err
+	}
+	var eventType, event, prompt string
+	switch kind {
+	case db.QuestionOwnership:
+		eventType = "ownership_question"
+		event = fmt.Sprintf("Changes by %s are outside the crew's ownership paths", role)
+		prompt = fmt.Sprintf("Ownership check for %s needs your input", role)
+	case db.QuestionBudget:
+		eventType = "budget_question"
+		event = "Budget exceeded; asking whether to continue"
+		prompt = "Budget check needs your input"
+	default:
+		eventType = "awaiting_user"
+		event = fmt.Sprintf("Worker %s is blocked and needs user input", role)
+		prompt = fmt.Sprintf("Worker %s needs your input", role)
+	}
+	_ = e.a.DB().CreateEvent(bg, r.execID, db.LevelWarn, eventType, event)
+
+	ch := make(chan string, 1)
+	r.mu.Lock()
+	r.questions[q.ID] = ch
+	first := len(r.questions) == 1
+	if first {
+		r.prevStep = r.step
+	}
+	r.mu.Unlock()
+	if first {
+		e.setStep(r, StepAwaitingUser)
+		e.setStatus(r, db.StatusAwaitingUser)
+	}
+
+	e.emit(r, fmt.Sprintf("%s (question #%d): %s", prompt, q.ID, question))
+	e.publish(Event{ExecutionID: r.execID, Kind: EventQuestion, Step: StepAwaitingUser, Message: question, QuestionID: q.ID, QuestionKind: kind})
+
+	var answer string
+	select {
+	case answer = <-ch:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	r.mu.Lock()
+	delete(r.questions, q.ID)
+	last := len(r.questions) == 0
+	prev := r.prevStep
+	r.mu.Unlock()
+	if last {
+		e.setStep(r, prev)
+		if ctx.Err() == nil {
+			e.setStatus(r, db.StatusRunning)
+		}
+	}
+	return answer, err
+}
+
+// setStatus records the execution's status, and its task's, while it runs.
+func (e *Engine) setStatus(r *run, status string) {
+	bg := context.Background()
+	if err := e.a.DB().UpdateExecutionStatus(bg, r.execID, status); err != nil {
+		e.emit(r, fmt.Sprintf("Warning: could not update execution status: %v", err))
+		return
+	}
+	if exec, err := e.a.DB().GetExecution(bg, r.execID); err == nil && exec != nil {
+		_ = e.a.DB().UpdateTaskStatus(bg, exec.TaskID, status)
+	}
+}
+
+// userGuidance returns a copy of the answers given so far in this run.
+func (r *run) userGuidance() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.guidance...)
+}
+
+// formatGuidance renders an answered question for inclusion in prompts.
+func formatGuidance(role, question, answer string) string {
+	return fmt.Sprintf("**Question from %s**: %s\n**User answer**: %s", role, question, answer)
+}
+
+// stop records why the run is being stopped and the status to record for
+// it, then cancels its context. Only the first call counts.
+func (r *run) stop(status, reason string) {
+	r.mu.Lock()
+	if r.stopped == "" {
+		r.stopped = reason
+		r.stopAs = status
+	}
+	r.mu.Unlock()
+	r.cancel()
+}
+
+// stopReason returns the status and reason passed to stop, or empty strings
+// if the run was not stopped.
+func (r *run) stopReason() (status, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stopAs, r.stopped
+}
+
+// waitUnpaused blocks while the run is paused. It returns ctx.Err() if the
+// execution is cancelled while waiting.
+func (r *run) waitUnpaused(ctx context.Context) error {
+	r.mu.Lock()
+	ch := r.unpaused
+	r.mu.Unlock()
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,75 @@
+package engine
+
+// EventKind identifies the type of an engine Event.
+type EventKind string
+
+const (
+	// EventStarted is published once an execution has been marked running.
+	EventStarted EventKind = "started"
+	// EventStep is published when the execution moves to a new phase.
+	EventStep EventKind = "step"
+	// EventOutput carries a single human-readable progress line.
+	EventOutput EventKind = "output"
+	// EventAgentOutput carries one line of raw Claude CLI output from a
+	// running agent. Agent names the agent's role and AgentRunID its
+	// agent_runs row.
+	EventAgentOutput EventKind = "agent_output"
+	// EventAgentStarted is published when a Boss or worker agent run is
+	// recorded and its CLI process is about to start.
+	EventAgentStarted EventKind = "agent_started"
+	// EventWorkerDone is published when a worker finishes, successfully or not.
+	EventWorkerDone EventKind = "worker_done"
+	// EventPaused and EventUnpaused report pause state changes.
+	EventPaused   EventKind = "paused"
+	EventUnpaused EventKind = "unpaused"
+	// EventQuestion is published when a question is put to the user: a
+	// worker blocker or budget question (alert_with_issues mode) or a change
+	// outside a crew's ownership paths (the ask policy). Message holds the
+	// question text, QuestionKind what it is about (one of the db
+	// question kinds) and QuestionID identifies it for Engine.Answer.
+	EventQuestion EventKind = "question"
+	// EventAnswered is published once an escalated question is answered.
+	EventAnswered EventKind = "answered"
+	// EventFinished is published exactly once when the execution goroutine
+	// exits. Status holds the final execution status.
+	EventFinished EventKind = "finished"
+)
+
+// Execution phases reported in Event.Step and Snapshot.Step.
+const (
+	StepStarting     = "starting"
+	StepBossPlan     = "boss_plan"
+	StepWorkers      = "workers"
+	StepBossReview   = "boss_review"
+	StepAwaitingUser = "awaiting_user"
+	StepBossSummary  = "boss_summary"
+	StepDone         = "done"
+)
+
+// Event is a progress notification for a single execution. Events are
+// delivered to every subscriber registered via Engine.Subscribe.
+type Event struct {
+	ExecutionID  int64     `json:"execution_id"`
+	Kind         EventKind `json:"kind"`
+	Step         string    `json:"step,omitempty"`
+	Message      string    `json:"message,omitempty"`
+	Status       string    `json:"status,omitempty"`
+	QuestionID   int64     `json:"question_id,omitempty"`
+	QuestionKind string    `json:"question_kind,omitempty"`
+	Agent        string    `json:"agent,omitempty"`
+	AgentRunID   int64     `json:"agent_run_id,omitempty"`
+}
+
+// Snapshot is a point-in-time view of an execution managed by the engine.
+// Front-ends use it to rebuild their state after navigating back to a run.
+type Snapshot struct {
+	ExecutionID  int64    `json:"execution_id"`
+	Running      bool     `json:"running"`
+	Paused       bool     `json:"paused"`
+	Step         string   `json:"step"`
+	WorkersTotal int      `json:"workers_total"`
+	WorkersDone  int      `json:"workers_done"`
+	Questions    int      `json:"open_questions"`
+	ActiveRuns   []int64  `json:"active_runs"`
+	Output       []string `json:"output"`
+}
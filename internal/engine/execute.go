@@ -0,0 +1,721 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"bore-tui/internal/agents"
+	"bore-tui/internal/app"
+	"bore-tui/internal/config"
+	"bore-tui/internal/db"
+	"bore-tui/internal/process"
+)
+
+// maxQuestionsPerWorker bounds how many times a single worker may escalate
+// blockers to the user before its last result is accepted as is.
+const maxQuestionsPerWorker = 3
+
+// workerOutcome is the result of a single worker run. result is nil if the
+// worker failed without producing a parseable WorkerResult.
+type workerOutcome struct {
+	result *agents.WorkerResult
+	runID  int64 // agent_runs row that produced result
+	err    error
+}
+
+// execute drives one execution from start to finish and returns its final
+// status. It is the body of the goroutine launched by Engine.start and
+// Engine.Resume; rs is nil unless an interrupted execution is being resumed.
+func (e *Engine) execute(ctx context.Context, r *run, exec *db.Execution, brief *agents.ExecutionBrief, rs *resumeState) string {
+	a := e.a
+	bg := context.Background()
+
+	// Mark execution as started.
+	if rs == nil {
+		if err := a.DB().SetExecutionStarted(bg, exec.ID, os.Getpid()); err != nil {
+			e.emit(r, fmt.Sprintf("Error: set execution started: %v", err))
+			return db.StatusFailed
+		}
+		_ = a.DB().CreateEvent(bg, exec.ID, db.LevelInfo, "execution_start", "Execution started")
+	} else {
+		if err := a.DB().ResumeExecution(bg, exec.ID, os.Getpid()); err != nil {
+			e.emit(r, fmt.Sprintf("Error: resume execution: %v", err))
+			return db.StatusInterrupted
+		}
+		_ = a.DB().CreateEvent(bg, exec.ID, db.LevelInfo, "execution_resume", "Execution resumed")
+	}
+	e.publish(Event{ExecutionID: exec.ID, Kind: EventStarted})
+	go e.heartbeat(r)
+
+	task, err := a.DB().GetTask(bg, exec.TaskID)
+	if err != nil {
+		e.emit(r, fmt.Sprintf("Error: load task for execution: %v", err))
+		return e.markFailed(exec.ID, nil)
+	}
+	if rs != nil {
+		_ = a.DB().UpdateTaskStatus(bg, task.ID, db.StatusRunning)
+	}
+
+	// Load crew if assigned.
+	var crew *db.Crew
+	if exec.CrewID != nil {
+		crew, err = a.DB().GetCrew(bg, *exec.CrewID)
+		if err != nil {
+			e.emit(r, fmt.Sprintf("Error: load crew: %v", err))
+			return e.markFailed(exec.ID, task)
+		}
+	}
+
+	// Build the brief.
+	var useBrief agents.ExecutionBrief
+	switch {
+	case rs != nil && rs.plan != nil:
+		useBrief = rs.brief
+	case brief != nil:
+		useBrief = *brief
+	default:
+		useBrief = buildBriefFromExec(exec, task)
+	}
+
+	if rs != nil {
+		r.mu.Lock()
+		r.guidance = rs.guidance
+		r.mu.Unlock()
+		r.planMu.Lock()
+		r.workers = rs.workers
+		r.planMu.Unlock()
+	}
+
+	budget, maxRounds := e.workerBudget()
+	bossCtx := agents.BossContext{
+		Crew:         crew,
+		Brief:        useBrief,
+		TaskPrompt:   task.Prompt,
+		Mode:         task.Mode,
+		WorkerBudget: budget,
+		UserGuidance: r.userGuidance(),
+	}
+
+	// Phase 1: Boss plan, unless a resumed execution already has one.
+	var pending []int
+	if rs != nil && rs.plan != nil {
+		pending = r.pendingWorkers()
+		total := r.spawnedCount()
+		r.mu.Lock()
+		r.total = total
+		r.finished = total - len(pending)
+		r.mu.Unlock()
+		e.emit(r, fmt.Sprintf("Execution resumed from its Boss plan: %d of %d workers already completed.",
+			total-len(pending), total))
+	} else {
+		e.setStep(r, StepBossPlan)
+		if rs != nil {
+			e.emit(r, "Execution resumed. No Boss plan was saved; running Boss plan...")
+		} else {
+			e.emit(r, "Execution started. Running Boss plan...")
+		}
+		e.checkBudget(r)
+		if !e.withinBudget(ctx, r, task) {
+			r.stop(db.StatusCancelled, "Execution stopped before the Boss plan: over budget")
+		}
+		if err := r.waitUnpaused(ctx); err != nil {
+			return e.markCancelled(r, task)
+		}
+		plan, err := e.runBossPlan(ctx, r, exec, bossCtx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return e.markCancelled(r, task)
+			}
+			e.emit(r, fmt.Sprintf("Error: %v", err))
+			return e.markFailed(exec.ID, task)
+		}
+		e.emit(r, fmt.Sprintf("Boss plan complete: %d steps, %d workers needed.",
+			len(plan.Steps), len(plan.NeedsWorkers)))
+		e.savePlan(r, useBrief, plan)
+		pending = e.spawnWorkers(r, e.capWorkers(exec.ID, r, plan.NeedsWorkers, budget))
+	}
+
+	// Phase 2: worker waves. After each wave the Boss is re-consulted and may
+	// spawn more workers (within the remaining budget) or finish directly
+	// with its summary.
+	var summary *agents.BossSummary
+	if len(pending) == 0 {
+		e.emit(r, "No workers pending. Running Boss summary...")
+	}
+	for round := 0; len(pending) > 0; round++ {
+		e.setStep(r, StepWorkers)
+		reported := e.runWorkers(ctx, r, exec, task, crew, pending)
+		if ctx.Err() != nil {
+			return e.markCancelled(r, task)
+		}
+		e.emit(r, fmt.Sprintf("Wave %d complete (%d/%d reported results).",
+			round+1, reported, len(pending)))
+
+		remaining := budget - r.spawnedCount()
+		if remaining <= 0 {
+			e.emit(r, "Worker budget exhausted. Running Boss summary...")
+			break
+		}
+		if round >= maxRounds {
+			e.emit(r, "Boss review limit reached. Running Boss summary...")
+			break
+		}
+		if !e.withinBudget(ctx, r, task) {
+			if ctx.Err() != nil {
+				return e.markCancelled(r, task)
+			}
+			e.emit(r, "Over budget. Running Boss summary...")
+			break
+		}
+
+		e.setStep(r, StepBossReview)
+		e.emit(r, "Asking Boss whether more workers are needed...")
+		if err := r.waitUnpaused(ctx); err != nil {
+			return e.markCancelled(r, task)
+		}
+		bossCtx.UserGuidance = r.userGuidance()
+		more, bs, err := e.runBossReview(ctx, r, exec, bossCtx, r.workerResults(), remaining)
+		if ctx.Err() != nil {
+			return e.markCancelled(r, task)
+		}
+		if err != nil {
+			e.emit(r, fmt.Sprintf("Boss review error: %v. Running Boss summary...", err))
+			break
+		}
+		if bs != nil {
+			summary = bs
+			break
+		}
+		needs := e.capWorkers(exec.ID, r, more, remaining)
+		if len(needs) == 0 {
+			e.emit(r, "Boss requested no further workers. Running Boss summary...")
+			break
+		}
+		e.emit(r, fmt.Sprintf("Boss requested %d more workers.", len(needs)))
+		pending = e.spawnWorkers(r, needs)
+	}
+
+	// Phase 3: Boss summary, unless the review already produced one.
+	e.setStep(r, StepBossSummary)
+	var summaryErr error
+	if summary == nil {
+		if err := r.waitUnpaused(ctx); err != nil {
+			return e.markCancelled(r, task)
+		}
+		bossCtx.UserGuidance = r.userGuidance()
+		summary, summaryErr = e.runBossSummary(ctx, r, exec, bossCtx, r.workerResults())
+		if ctx.Err() != nil {
+			return e.markCancelled(r, task)
+		}
+	}
+	status, err := e.finishExecution(exec, task, summary)
+	if err != nil && summaryErr == nil {
+		summaryErr = err
+	}
+	switch {
+	case summaryErr != nil:
+		e.emit(r, fmt.Sprintf("Boss summary error: %v", summaryErr))
+	case summary != nil:
+		e.emit(r, fmt.Sprintf("Execution finished: %s", summary.Outcome))
+	default:
+		e.emit(r, "Execution finished.")
+	}
+	e.setStep(r, StepDone)
+	return status
+}
+
+// runBossPlan runs the Boss plan phase and records it as an agent run.
+func (e *Engine) runBossPlan(ctx context.Context, r *run, exec *db.Execution, bossCtx agents.BossContext) (*agents.BossPlan, error) {
+	a := e.a
+	bg := context.Background()
+
+	_ = a.DB().CreateEvent(bg, exec.ID, db.LevelInfo, "boss_plan", "Running Boss plan phase")
+
+	bossSystemPrompt := agents.BuildBossSystemPrompt(bossCtx)
+	bossPlanPrompt := agents.BuildBossPlanPrompt(bossCtx)
+	fullBossPrompt := bossSystemPrompt + "\n\n" + bossPlanPrompt
+
+	ar := e.beginAgentRun(r, exec.ID, db.AgentTypeBoss, "planner", fullBossPrompt)
+	onStdout, onStderr := e.streamOutput(r, ar)
+	bossResult, parsed, err := e.runAgent(ctx, r, ar, config.RoleBoss, exec.WorktreePath, fullBossPrompt, process.RunOptions{
+		OnStdout: onStdout, OnStderr: onStderr, Permissions: process.ReadOnly(),
+	})
+	if bossResult.Err != nil {
+		e.finishAgentRun(r, ar, fmt.Sprintf("Failed: %v", bossResult.Err), db.OutcomeFailed, "")
+		return nil, fmt.Errorf("boss plan: %w", bossResult.Err)
+	}
+
+	if bossResult.JSONBlock == "" {
+		e.finishAgentRun(r, ar, "No JSON output", db.OutcomeFailed, "")
+		return nil, fmt.Errorf("boss plan: no JSON response")
+	}
+
+	if err != nil {
+		e.finishAgentRun(r, ar, fmt.Sprintf("Parse error: %v", err), db.OutcomeFailed, "")
+		return nil, fmt.Errorf("boss plan parse: %w", err)
+	}
+
+	plan, ok := parsed.(agents.BossPlan)
+	if !ok {
+		e.finishAgentRun(r, ar, fmt.Sprintf("Unexpected response type %T", parsed), db.OutcomeFailed, "")
+		return nil, fmt.Errorf("boss plan: unexpected type %T", parsed)
+	}
+
+	e.finishAgentRun(r, ar, fmt.Sprintf("Plan with %d steps, %d workers", len(plan.Steps), len(plan.NeedsWorkers)),
+		db.OutcomeSuccess, strings.Join(plan.EstimatedFiles, ", "))
+	e.recordBossPlan(r, ar, plan)
+
+	_ = a.DB().CreateEvent(bg, exec.ID, db.LevelInfo, "boss_plan_done",
+		fmt.Sprintf("Boss plan: %d steps, %d workers needed", len(plan.Steps), len(plan.NeedsWorkers)))
+
+	return &plan, nil
+}
+
+// runWorkers runs the workers at the given indices of the run's worker list
+// concurrently, bounded by the per-execution worker limit and the global
+// scheduler. Each result is recorded as soon as its worker finishes so an
+// interrupted execution can be resumed. Once the wave is done, changes no
+// worker reported are checked against the crew's ownership paths. It
+// returns the number of workers that reported a result.
+func (e *Engine) runWorkers(ctx context.Context, r *run, exec *db.Execution, task *db.Task, crew *db.Crew, idx []int) int {
+	limit := e.workerLimit(task)
+	e.emit(r, fmt.Sprintf("Dispatching %d workers (up to %d in parallel)...", len(idx), limit))
+
+	total := r.spawnedCount()
+	r.mu.Lock()
+	r.total = total
+	r.mu.Unlock()
+
+	capacity := make(chan struct{}, limit)
+	slots := make([]workerOutcome, len(idx))
+
+	var wg sync.WaitGroup
+	for j, i := range idx {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n := i + 1
+			need := r.workerNeed(i)
+			slots[j] = e.runEscalatingWorker(ctx, r, capacity, exec, task, crew, n, total, need)
+			if slots[j].result != nil {
+				e.completeWorker(r, i, slots[j].runID, slots[j].result)
+			}
+
+			r.mu.Lock()
+			r.finished++
+			r.mu.Unlock()
+
+			if slots[j].err != nil {
+				e.emit(r, fmt.Sprintf("Worker %d (%s) error: %v", n, need.Role, slots[j].err))
+			} else {
+				e.emit(r, fmt.Sprintf("Worker %d (%s) finished: %s", n, need.Role, slots[j].result.Outcome))
+			}
+			e.publish(Event{ExecutionID: exec.ID, Kind: EventWorkerDone, Step: StepWorkers})
+		}()
+	}
+	wg.Wait()
+	e.enforceOwnership(ctx, r, exec, crew, "workers", nil, nil)
+
+	reported := 0
+	for _, s := range slots {
+		if s.result != nil {
+			reported++
+		}
+	}
+	return reported
+}
+
+// runEscalatingWorker runs a worker and, in alert_with_issues mode, escalates
+// its blockers to the user. Each non-empty answer re-runs the worker with the
+// answer injected as guidance; an empty answer, or reaching
+// maxQuestionsPerWorker, accepts the latest result. The worker's capacity
+// slot is released while it waits for the user.
+func (e *Engine) runEscalatingWorker(ctx context.Context, r *run, capacity chan struct{}, exec *db.Execution, task *db.Task, crew *db.Crew, workerNum, totalWorkers int, need agents.WorkerNeed) workerOutcome {
+	var guidance []string
+	for asked := 0; ; asked++ {
+		out := e.runWorker(ctx, r, capacity, exec, task, crew, workerNum, totalWorkers, need, guidance)
+		question := escalationQuestion(task, need.Role, out.result)
+		if question == "" || asked >= maxQuestionsPerWorker || ctx.Err() != nil {
+			return out
+		}
+
+		answer, err := e.ask(ctx, r, db.QuestionBlocker, need.Role, question)
+		if err != nil {
+			if ctx.Err() == nil {
+				e.emit(r, fmt.Sprintf("Worker %d (%s): could not escalate blocker: %v", workerNum, need.Role, err))
+			}
+			return out
+		}
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			e.emit(r, fmt.Sprintf("Worker %d (%s): result accepted without changes.", workerNum, need.Role))
+			return out
+		}
+		guidance = append(guidance, formatGuidance(need.Role, question, answer))
+		e.emit(r, fmt.Sprintf("Re-running worker %d (%s) with your answer...", workerNum, need.Role))
+	}
+}
+
+// escalationQuestion returns the question to put to the user for a worker
+// result, or "" if the result should not be escalated. Only tasks in
+// alert_with_issues mode escalate, and only for results that report
+// blockers or a partial outcome.
+func escalationQuestion(task *db.Task, role string, wr *agents.WorkerResult) string {
+	if task.Mode != db.ModeAlertWithIssues || wr == nil {
+		return ""
+	}
+	if len(wr.Blockers) > 0 {
+		return strings.Join(wr.Blockers, "\n")
+	}
+	if wr.Outcome == db.OutcomePartial {
+		return fmt.Sprintf("Worker %s only partially completed its goal: %s\nHow should it proceed?", role, wr.Summary)
+	}
+	return ""
+}
+
+// runWorker runs a single worker; workerNum is its 1-based number within the
+// execution. It holds a per-execution capacity slot and a global scheduler
+// slot for the duration of the Claude CLI call.
+func (e *Engine) runWorker(ctx context.Context, r *run, capacity chan struct{}, exec *db.Execution, task *db.Task, crew *db.Crew, workerNum, totalWorkers int, workerNeed agents.WorkerNeed, guidance []string) workerOutcome {
+	a := e.a
+	bg := context.Background()
+
+	select {
+	case capacity <- struct{}{}:
+	case <-ctx.Done():
+		return workerOutcome{err: fmt.Errorf("worker %s: %w", workerNeed.Role, ctx.Err())}
+	}
+	release := sync.OnceFunc(func() { <-capacity })
+	defer release()
+
+	if err := r.waitUnpaused(ctx); err != nil {
+		return workerOutcome{err: fmt.Errorf("worker %s: %w", workerNeed.Role, err)}
+	}
+	if !e.withinBudget(ctx, r, task) {
+		return workerOutcome{err: fmt.Errorf("worker %s: not started: over budget", workerNeed.Role)}
+	}
+
+	// Acquire scheduler slot.
+	if err := a.Scheduler().Acquire(ctx); err != nil {
+		_ = a.DB().CreateEvent(bg, exec.ID, db.LevelError, "scheduler_error", err.Error())
+		return workerOutcome{err: fmt.Errorf("scheduler: %w", err)}
+	}
+
+	_ = a.DB().CreateEvent(bg, exec.ID, db.LevelInfo, "worker_start",
+		fmt.Sprintf("Starting worker %d/%d: %s", workerNum, totalWorkers, workerNeed.Role))
+	e.emit(r, fmt.Sprintf("Starting worker %d/%d: %s...", workerNum, totalWorkers, workerNeed.Role))
+
+	workerCtx := agents.WorkerContext{
+		Role:            workerNeed.Role,
+		Goal:            workerNeed.Goal,
+		FilesOrPaths:    workerNeed.FilesOrPaths,
+		AllowedCommands: workerNeed.Commands,
+		SuccessCriteria: workerNeed.SuccessCriteria,
+		Mode:            task.Mode,
+		UserGuidance:    guidance,
+	}
+	if crew != nil {
+		workerCtx.CrewObjective = crew.Objective
+		workerCtx.CrewConstraints = crew.Constraints
+	}
+
+	workerPrompt := agents.BuildWorkerSystemPrompt(workerCtx)
+	ar := e.beginAgentRun(r, exec.ID, db.AgentTypeWorker, workerNeed.Role, workerPrompt)
+	onStdout, onStderr := e.streamOutput(r, ar)
+	workerResult, parsedWorker, err := e.runAgent(ctx, r, ar, config.RoleWorker, exec.WorktreePath, workerPrompt, process.RunOptions{
+		OnStdout: onStdout, OnStderr: onStderr, Permissions: agents.WorkerPermissions(crew, workerNeed),
+		Model: crewModel(crew),
+	})
+
+	a.Scheduler().Release()
+
+	if workerResult.Err != nil {
+		_ = a.DB().CreateEvent(bg, exec.ID, db.LevelError, "worker_error",
+			fmt.Sprintf("Worker %s failed: %v", workerNeed.Role, workerResult.Err))
+
+		e.finishAgentRun(r, ar, fmt.Sprintf("Failed: %v", workerResult.Err), db.OutcomeFailed, "")
+		return workerOutcome{err: fmt.Errorf("worker %s: %w", workerNeed.Role, workerResult.Err)}
+	}
+
+	if workerResult.JSONBlock == "" {
+		_ = a.DB().CreateEvent(bg, exec.ID, db.LevelWarn, "worker_no_json",
+			fmt.Sprintf("Worker %s returned no JSON", workerNeed.Role))
+
+		e.finishAgentRun(r, ar, "No JSON output", db.OutcomeFailed, "")
+		return workerOutcome{err: fmt.Errorf("worker %s: no JSON response", workerNeed.Role)}
+	}
+
+	if err != nil {
+		_ = a.DB().CreateEvent(bg, exec.ID, db.LevelWarn, "worker_parse_error",
+			fmt.Sprintf("Worker %s parse error: %v", workerNeed.Role, err))
+
+		e.finishAgentRun(r, ar, fmt.Sprintf("Parse error: %v", err), db.OutcomeFailed, "")
+		return workerOutcome{err: fmt.Errorf("worker %s parse: %w", workerNeed.Role, err)}
+	}
+
+	wr, ok := parsedWorker.(agents.WorkerResult)
+	if !ok {
+		_ = a.DB().CreateEvent(bg, exec.ID, db.LevelWarn, "worker_type_error",
+			fmt.Sprintf("Worker %s returned unexpected type %T", workerNeed.Role, parsedWorker))
+		e.finishAgentRun(r, ar, fmt.Sprintf("Unexpected response type %T", parsedWorker), db.OutcomeFailed, "")
+		return workerOutcome{err: fmt.Errorf("worker %s: unexpected type %T", workerNeed.Role, parsedWorker)}
+	}
+
+	e.enforceOwnership(ctx, r, exec, crew, workerNeed.Role, &wr, release)
+
+	// Save worker result to DB.
+	e.finishAgentRun(r, ar, wr.Summary, wr.Outcome, strings.Join(wr.FilesChanged, ", "))
+	e.recordWorkerResult(r, ar, workerNeed.Role, wr)
+
+	_ = a.DB().CreateEvent(bg, exec.ID, db.LevelInfo, "worker_done",
+		fmt.Sprintf("Worker %s finished: %s", workerNeed.Role, wr.Outcome))
+
+	return workerOutcome{result: &wr, runID: ar.id}
+}
+
+// runBossReview asks the Boss to review results so far. It returns either
+// additional worker needs or a final summary.
+func (e *Engine) runBossReview(ctx context.Context, r *run, exec *db.Execution, bossCtx agents.BossContext, workerResults []agents.WorkerResult, remaining int) ([]agents.WorkerNeed, *agents.BossSummary, error) {
+	a := e.a
+	bg := context.Background()
+
+	_ = a.DB().CreateEvent(bg, exec.ID, db.LevelInfo, "boss_review",
+		fmt.Sprintf("Running Boss review (%d workers remaining in budget)", remaining))
+
+	prompt := agents.BuildBossSystemPrompt(bossCtx) + "\n\n" + agents.BuildBossReviewPrompt(workerResults, remaining)
+	ar := e.beginAgentRun(r, exec.ID, db.AgentTypeBoss, "reviewer", prompt)
+	onStdout, onStderr := e.streamOutput(r, ar)
+	result, parsed, err := e.runAgent(ctx, r, ar, config.RoleBoss, exec.WorktreePath, prompt, process.RunOptions{
+		OnStdout: onStdout, OnStderr: onStderr, Permissions: process.ReadOnly(),
+	})
+	if result.Err != nil {
+		e.finishAgentRun(r, ar, fmt.Sprintf("Failed: %v", result.Err), db.OutcomeFailed, "")
+		return nil, nil, fmt.Errorf("boss review: %w", result.Err)
+	}
+	if result.JSONBlock == "" {
+		e.finishAgentRun(r, ar, "No JSON output", db.OutcomeFailed, "")
+		return nil, nil, fmt.Errorf("boss review: no JSON response")
+	}
+
+	if err != nil {
+		e.finishAgentRun(r, ar, fmt.Sprintf("Parse error: %v", err), db.OutcomeFailed, "")
+		return nil, nil, fmt.Errorf("boss review parse: %w", err)
+	}
+
+	switch v := parsed.(type) {
+	case agents.SpawnWorkersRequest:
+		e.finishAgentRun(r, ar, fmt.Sprintf("Requested %d more workers", len(v.Workers)), db.OutcomeSuccess, "")
+		_ = a.DB().CreateEvent(bg, exec.ID, db.LevelInfo, "boss_spawn_workers",
+			fmt.Sprintf("Boss requested %d more workers", len(v.Workers)))
+		return v.Workers, nil, nil
+	case agents.BossSummary:
+		e.finishAgentRun(r, ar, strings.Join(v.WhatChanged, "; "), v.Outcome, strings.Join(v.FilesTouched, ", "))
+		return nil, &v, nil
+	default:
+		e.finishAgentRun(r, ar, fmt.Sprintf("Unexpected response type %T", parsed), db.OutcomeFailed, "")
+		return nil, nil, fmt.Errorf("boss review: unexpected type %T", parsed)
+	}
+}
+
+// runBossSummary asks the Boss for its final summary. A nil summary with a
+// nil error means the Boss responded without a usable summary.
+func (e *Engine) runBossSummary(ctx context.Context, r *run, exec *db.Execution, bossCtx agents.BossContext, workerResults []agents.WorkerResult) (*agents.BossSummary, error) {
+	a := e.a
+	bg := context.Background()
+
+	_ = a.DB().CreateEvent(bg, exec.ID, db.LevelInfo, "boss_summary", "Running Boss summary phase")
+
+	bossSystemPrompt := agents.BuildBossSystemPrompt(bossCtx)
+	bossSummaryPrompt := bossSystemPrompt + "\n\n" + agents.BuildBossSummaryPrompt(workerResults)
+	ar := e.beginAgentRun(r, exec.ID, db.AgentTypeBoss, "summarizer", bossSummaryPrompt)
+	onStdout, onStderr := e.streamOutput(r, ar)
+	summaryResult, parsedSummary, err := e.runAgent(ctx, r, ar, config.RoleBoss, exec.WorktreePath, bossSummaryPrompt, process.RunOptions{
+		OnStdout: onStdout, OnStderr: onStderr, Permissions: process.ReadOnly(),
+	})
+
+	if summaryResult.Err != nil {
+		_ = a.DB().CreateEvent(bg, exec.ID, db.LevelError, "boss_summary_error",
+			fmt.Sprintf("Boss summary failed: %v", summaryResult.Err))
+		e.finishAgentRun(r, ar, fmt.Sprintf("Failed: %v", summaryResult.Err), db.OutcomeFailed, "")
+		return nil, summaryResult.Err
+	}
+	if summaryResult.JSONBlock == "" {
+		e.finishAgentRun(r, ar, "No JSON output", db.OutcomeFailed, "")
+		return nil, nil
+	}
+	if err != nil {
+		e.finishAgentRun(r, ar, fmt.Sprintf("Parse error: %v", err), db.OutcomeFailed, "")
+		return nil, nil
+	}
+	bs, ok := parsedSummary.(agents.BossSummary)
+	if !ok {
+		e.finishAgentRun(r, ar, fmt.Sprintf("Unexpected response type %T", parsedSummary), db.OutcomeFailed, "")
+		return nil, nil
+	}
+	e.finishAgentRun(r, ar, strings.Join(bs.WhatChanged, "; "), bs.Outcome, strings.Join(bs.FilesTouched, ", "))
+	return &bs, nil
+}
+
+// finishExecution records the Boss summary's lessons, then marks the
+// execution and task finished. It returns the final status.
+func (e *Engine) finishExecution(exec *db.Execution, task *db.Task, bs *agents.BossSummary) (string, error) {
+	a := e.a
+	bg := context.Background()
+
+	finalStatus := db.StatusCompleted
+	if bs != nil {
+		// Save lessons.
+		for _, lesson := range bs.Lessons {
+			_ = a.DB().CreateLesson(bg, exec.ID, db.AgentTypeBoss, lesson.LessonType, lesson.Content)
+		}
+
+		// Set final status based on outcome.
+		switch bs.Outcome {
+		case "failed":
+			finalStatus = db.StatusFailed
+		case "partial":
+			finalStatus = db.StatusDiffReview
+		default:
+			finalStatus = db.StatusDiffReview
+		}
+	}
+
+	// Mark execution finished.
+	if err := a.DB().SetExecutionFinished(bg, exec.ID, finalStatus); err != nil {
+		return finalStatus, fmt.Errorf("set execution finished: %w", err)
+	}
+
+	// Update task status.
+	_ = a.DB().UpdateTaskStatus(bg, task.ID, finalStatus)
+
+	_ = a.DB().CreateEvent(bg, exec.ID, db.LevelInfo, "execution_done",
+		fmt.Sprintf("Execution finished with status: %s", finalStatus))
+
+	return finalStatus, nil
+}
+
+// capWorkers truncates needs to at most limit entries, recording a warning
+// when the Boss asked for more than the budget allows.
+func (e *Engine) capWorkers(execID int64, r *run, needs []agents.WorkerNeed, limit int) []agents.WorkerNeed {
+	if len(needs) <= limit {
+		return needs
+	}
+	msg := fmt.Sprintf("Boss requested %d workers but only %d remain in the budget; dropping the rest", len(needs), limit)
+	_ = e.a.DB().CreateEvent(context.Background(), execID, db.LevelWarn, "worker_budget", msg)
+	e.emit(r, msg+".")
+	return needs[:limit]
+}
+
+// workerBudget returns the total number of workers a Boss may spawn for one
+// execution and the number of review rounds allowed after the first wave.
+func (e *Engine) workerBudget() (budget, rounds int) {
+	cfg := e.a.Config()
+	if cfg == nil {
+		return 3, 0
+	}
+	budget = cfg.Agents.WorkerBudget
+	if budget < 1 {
+		budget = 1
+	}
+	return budget, cfg.Agents.MaxBossRounds
+}
+
+// workerLimit returns how many workers of this execution may run at once,
+// derived from the task complexity and capped by max_total_workers.
+func (e *Engine) workerLimit(task *db.Task) int {
+	cfg := e.a.Config()
+	if cfg == nil {
+		return 1
+	}
+	limit := cfg.Agents.MaxWorkersFor(task.Complexity)
+	if cfg.Agents.MaxTotalWorkers > 0 && limit > cfg.Agents.MaxTotalWorkers {
+		limit = cfg.Agents.MaxTotalWorkers
+	}
+	return limit
+}
+
+// crewModel returns the crew's worker model, or "" if the crew has none.
+func crewModel(crew *db.Crew) string {
+	if crew == nil {
+		return ""
+	}
+	return crew.Model
+}
+
+// heartbeat records that this process still owns the run's execution every
+// app.OwnerHeartbeat until the run is done, so that crash recovery in other
+// processes leaves the execution alone.
+func (e *Engine) heartbeat(r *run) {
+	t := time.NewTicker(app.OwnerHeartbeat)
+	defer t.Stop()
+	pid := os.Getpid()
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-t.C:
+			d := e.a.DB()
+			if d == nil {
+				return
+			}
+			if err := d.TouchExecutionOwner(context.Background(), r.execID, pid); err != nil {
+				if logs := e.a.Logs(); logs != nil {
+					logs.System.Warn("engine: execution %d heartbeat: %v", r.execID, err)
+				}
+			}
+		}
+	}
+}
+
+// markFailed marks the execution (and task, if known) as failed and closes
+// any question left unanswered.
+func (e *Engine) markFailed(execID int64, task *db.Task) string {
+	ctx := context.Background()
+	_, _ = e.a.DB().CloseOpenQuestions(ctx, execID)
+	_ = e.a.DB().SetExecutionFinished(ctx, execID, db.StatusFailed)
+	if task != nil {
+		_ = e.a.DB().UpdateTaskStatus(ctx, task.ID, db.StatusFailed)
+	}
+	return db.StatusFailed
+}
+
+// markCancelled records a cancellation, marks agent runs that were killed
+// mid-flight as failed, closes unanswered questions, and marks the
+// execution (and task, if known) as cancelled or interrupted, as requested
+// by stop. The worktree is left in place for review.
+func (e *Engine) markCancelled(r *run, task *db.Task) string {
+	ctx := context.Background()
+	execID := r.execID
+	status, reason := r.stopReason()
+	if status == "" {
+		status = db.StatusInterrupted
+	}
+	if reason == "" {
+		reason = "Execution cancelled"
+	}
+	_ = e.a.DB().CreateEvent(ctx, execID, db.LevelWarn, "cancelled", reason)
+	if n, err := e.a.DB().FailInProgressAgentRuns(ctx, execID, reason); err == nil && n > 0 {
+		e.emit(r, fmt.Sprintf("Marked %d running agent(s) as failed.", n))
+	}
+	_, _ = e.a.DB().CloseOpenQuestions(ctx, execID)
+	e.emit(r, reason+". The worktree has been left in place for review.")
+	_ = e.a.DB().SetExecutionFinished(ctx, execID, status)
+	if task != nil {
+		_ = e.a.DB().UpdateTaskStatus(ctx, task.ID, status)
+	}
+	return status
+}
+
+// buildBriefFromExec creates a minimal ExecutionBrief from an execution and task.
+// In V1, the brief may not have been fully saved; this provides defaults.
+func buildBriefFromExec(exec *db.Execution, task *db.Task) agents.ExecutionBrief {
+	return agents.ExecutionBrief{
+		Type:       "execution_brief",
+		BaseBranch: exec.BaseBranch,
+		TaskTitle:  task.Title,
+	}
+}
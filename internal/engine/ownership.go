@@ -0,0 +1,177 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"bore-tui/internal/agents"
+	"bore-tui/internal/config"
+	"bore-tui/internal/db"
+)
+
+// enforceOwnership checks a worker's changes against the crew's ownership
+// paths. Workers of one execution share a worktree and run concurrently, so
+// a worker is only charged for the changed files it reported in
+// wr.FilesChanged; with wr nil, as after each wave when no worker is
+// running, every changed file is checked, which catches changes no worker
+// reported. Violations are recorded as a policy_violation event and, for a
+// worker, its result is downgraded to partial and the Boss is told in its
+// notes; the files themselves are then kept, reverted or put to the user
+// according to agents.ownership_policy. release, if not nil, gives up the
+// worker's capacity slot before the user is asked. A file is only reported
+// once per execution.
+func (e *Engine) enforceOwnership(ctx context.Context, r *run, exec *db.Execution, crew *db.Crew, role string, wr *agents.WorkerResult, release func()) {
+	if crew == nil {
+		return
+	}
+	globs := ownershipGlobs(crew.OwnershipPaths)
+	if len(globs) == 0 {
+		return
+	}
+	bg := context.Background()
+
+	changed, err := e.a.Repo().ChangedFiles(bg, exec.WorktreePath)
+	if err != nil {
+		e.emit(r, fmt.Sprintf("Warning: could not check ownership paths: %v", err))
+		return
+	}
+	if wr != nil {
+		reported := make(map[string]bool, len(wr.FilesChanged))
+		for _, f := range wr.FilesChanged {
+			reported[worktreeRelative(exec.WorktreePath, f)] = true
+		}
+		changed = slices.DeleteFunc(changed, func(f string) bool { return !reported[f] })
+	}
+	var outside []string
+	r.mu.Lock()
+	for _, f := range changed {
+		if !owned(globs, f) && !r.offside[f] {
+			r.offside[f] = true
+			outside = append(outside, f)
+		}
+	}
+	r.mu.Unlock()
+	if len(outside) == 0 {
+		return
+	}
+
+	var msg string
+	if wr != nil {
+		msg = fmt.Sprintf("Worker %s changed files outside crew %s's ownership paths (%s): %s",
+			role, crew.Name, strings.Join(globs, ", "), strings.Join(outside, ", "))
+	} else {
+		msg = fmt.Sprintf("Files outside crew %s's ownership paths (%s) changed without a worker reporting them: %s",
+			crew.Name, strings.Join(globs, ", "), strings.Join(outside, ", "))
+	}
+	_ = e.a.DB().CreateEvent(bg, exec.ID, db.LevelWarn, "policy_violation", msg)
+	e.emit(r, msg)
+	note := func(string) {}
+	if wr != nil {
+		if wr.Outcome == db.OutcomeSuccess {
+			wr.Outcome = db.OutcomePartial
+		}
+		note = func(s string) { wr.Notes = append(wr.Notes, s) }
+	}
+
+	policy := config.OwnershipRecord
+	if cfg := e.a.Config(); cfg != nil {
+		policy = cfg.Agents.OwnershipPolicy
+	}
+	revert := policy == config.OwnershipRevert
+	if policy == config.OwnershipAsk {
+		if release != nil {
+			release()
+		}
+		question := fmt.Sprintf("%s\nReply \"keep\" to keep these changes; any other reply reverts them.", msg)
+		answer, err := e.ask(ctx, r, db.QuestionOwnership, role, question)
+		if err != nil {
+			// Cancelled or interrupted: leave the files for diff review.
+			note("Changes outside the crew's ownership paths were left for review: " + strings.Join(outside, ", "))
+			return
+		}
+		revert = !strings.EqualFold(strings.TrimSpace(answer), "keep")
+	}
+
+	if !revert {
+		note("Changes outside the crew's ownership paths were kept: " + strings.Join(outside, ", "))
+		return
+	}
+	if err := e.a.Repo().DiscardPaths(bg, exec.WorktreePath, outside); err != nil {
+		_ = e.a.DB().CreateEvent(bg, exec.ID, db.LevelError, "policy_revert",
+			fmt.Sprintf("Could not revert files outside the ownership paths: %v", err))
+		e.emit(r, fmt.Sprintf("Warning: could not revert files outside the ownership paths: %v", err))
+		return
+	}
+	_ = e.a.DB().CreateEvent(bg, exec.ID, db.LevelInfo, "policy_revert",
+		"Reverted files outside the ownership paths: "+strings.Join(outside, ", "))
+	e.emit(r, "Reverted files outside the ownership paths: "+strings.Join(outside, ", "))
+	note("Changes outside the crew's ownership paths were reverted: " + strings.Join(outside, ", "))
+}
+
+// worktreeRelative returns a path a worker reported, which may be absolute
+// or start with "./", relative to the worktree at dir and slash-separated,
+// as git reports changed files.
+func worktreeRelative(dir, file string) string {
+	if filepath.IsAbs(file) {
+		if rel, err := filepath.Rel(dir, file); err == nil {
+			file = rel
+		}
+	}
+	return path.Clean(filepath.ToSlash(file))
+}
+
+// ownershipGlobs splits a crew's comma-separated ownership paths into
+// slash-separated globs without leading "./" or "/" and trailing "/".
+func ownershipGlobs(paths string) []string {
+	var globs []string
+	for _, g := range strings.Split(paths, ",") {
+		g = strings.TrimSpace(g)
+		g = strings.TrimPrefix(g, "./")
+		g = strings.Trim(g, "/")
+		if g != "" {
+			globs = append(globs, g)
+		}
+	}
+	return globs
+}
+
+// owned reports whether file, relative to the worktree, is covered by one
+// of globs. A glob covers the paths it matches and everything below them,
+// so "web" and "web/*" both cover "web/src/app.js". Each path element is
+// matched with path.Match; "**" matches any number of elements.
+func owned(globs []string, file string) bool {
+	parts := strings.Split(file, "/")
+	for _, g := range globs {
+		if matchPrefix(strings.Split(g, "/"), parts) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPrefix reports whether the glob elements pat match a leading run of
+// the path elements parts.
+func matchPrefix(pat, parts []string) bool {
+	if len(pat) == 0 {
+		return true
+	}
+	if pat[0] == "**" {
+		for i := 0; i <= len(parts); i++ {
+			if matchPrefix(pat[1:], parts[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(parts) == 0 {
+		return false
+	}
+	if ok, err := path.Match(pat[0], parts[0]); err != nil || !ok {
+		return false
+	}
+	return matchPrefix(pat[1:], parts[1:])
+}
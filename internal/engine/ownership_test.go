@@ -0,0 +1,111 @@
+//go:build unix
+
+package engine_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bore-tui/internal/config"
+	"bore-tui/internal/db"
+)
+
+// trespassingAgent plans an alpha and a beta worker for a crew owning src/.
+// Both also write a file under docs/, but only alpha reports it.
+const trespassingAgent = `#!/bin/sh
+prompt=$(cat)
+case "$prompt" in
+*"Produce a final summary of the execution"*)
+	echo '{"type":"boss_summary","outcome":"success","what_changed":["Added src files"]}' ;;
+*"Create a step-by-step plan"*)
+	echo '{"type":"boss_plan","steps":[{"id":"s1","title":"Write src files"}],"needs_workers":[{"role":"alpha","goal":"Write src/a.txt"},{"role":"beta","goal":"Write src/b.txt"}]}' ;;
+*"**Role**: alpha"*)
+	mkdir -p src docs
+	echo a > src/a.txt
+	echo a > docs/alpha.md
+	echo '{"type":"worker_result","outcome":"success","summary":"Wrote src/a.txt","files_changed":["src/a.txt","./docs/alpha.md"]}' ;;
+*"**Role**: beta"*)
+	mkdir -p src docs
+	echo b > src/b.txt
+	echo b > docs/beta.md
+	echo '{"type":"worker_result","outcome":"success","summary":"Wrote src/b.txt","files_changed":["src/b.txt"]}' ;;
+*)
+	echo "unexpected prompt" >&2
+	exit 1 ;;
+esac
+`
+
+// TestOwnershipChargesReportedFiles checks that a worker is only charged for
+// files outside its crew's ownership paths that it reported changing, and
+// that unreported changes are caught once the wave is done. Under the
+// revert policy both are reverted.
+func TestOwnershipChargesReportedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	t.Setenv("HOME", t.TempDir())
+
+	root := t.TempDir()
+	script := filepath.Join(root, "agent.sh")
+	if err := os.WriteFile(script, []byte(trespassingAgent), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	a := openCluster(t, filepath.Join(root, "repo"), func(ac *config.AgentsConfig) {
+		ac.Backends = map[string]config.BackendConfig{
+			"scripted": {Type: config.BackendTypeCommand, Command: []string{"/bin/sh", script}},
+		}
+		ac.RoleBackends = map[string]string{config.RoleBoss: "scripted", config.RoleWorker: "scripted"}
+		ac.MaxBossRounds = 0
+		ac.OwnershipPolicy = config.OwnershipRevert
+	})
+
+	ctx := context.Background()
+	d := a.DB()
+	crew, err := d.CreateCrew(ctx, a.Cluster().ID, "source", "Write source files", "", "", "src", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex := runTask(t, a, &crew.ID)
+	if ex.Status != db.StatusDiffReview {
+		t.Fatalf("execution status = %q, want %q", ex.Status, db.StatusDiffReview)
+	}
+
+	results, err := d.ListWorkerResults(ctx, ex.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outcomes := make(map[string]string)
+	for _, wr := range results {
+		outcomes[wr.Role] = wr.Outcome
+	}
+	if outcomes["alpha"] != db.OutcomePartial || outcomes["beta"] != db.OutcomeSuccess {
+		t.Errorf("worker outcomes = %v, want alpha partial and beta success", outcomes)
+	}
+
+	events, err := d.ListEvents(ctx, ex.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var violations []string
+	for _, ev := range events {
+		if ev.EventType == "policy_violation" {
+			violations = append(violations, ev.Message)
+		}
+	}
+	if len(violations) != 2 ||
+		!strings.HasPrefix(violations[0], "Worker alpha ") || !strings.HasSuffix(violations[0], ": docs/alpha.md") ||
+		!strings.Contains(violations[1], "without a worker reporting them: docs/beta.md") {
+		t.Errorf("policy violations = %q, want alpha's docs/alpha.md, then the unreported docs/beta.md", violations)
+	}
+
+	for file, want := range map[string]bool{"src/a.txt": true, "src/b.txt": true, "docs/alpha.md": false, "docs/beta.md": false} {
+		_, err := os.Stat(filepath.Join(ex.WorktreePath, file))
+		if exists := err == nil; exists != want {
+			t.Errorf("%s exists = %v, want %v", file, exists, want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"bore-tui/internal/db"
+	"bore-tui/internal/git"
+)
+
+// Prepare creates a pending execution for task: it records the execution in
+// the DB, creates its git worktree on a fresh execution branch off
+// baseBranch, and marks the task running. crewID, if not nil, assigns the
+// execution to a crew. The returned execution is ready to be passed to Start
+// or StartWithBrief.
+func (e *Engine) Prepare(ctx context.Context, task *db.Task, baseBranch string, crewID *int64) (*db.Execution, error) {
+	a := e.a
+	if a.DB() == nil || a.Repo() == nil {
+		return nil, fmt.Errorf("engine: prepare: no cluster open")
+	}
+
+	if baseBranch == "" {
+		baseBranch = "main"
+	}
+
+	// Look up thread name for branch naming.
+	thread, err := a.DB().GetThread(ctx, task.ThreadID)
+	if err != nil {
+		return nil, fmt.Errorf("engine: prepare: get thread for branch name: %w", err)
+	}
+
+	execBranch := git.MakeExecBranch(thread.Name, task.ID, task.Title)
+	worktreePath := fmt.Sprintf("%s/worktrees/%s", a.BoreDir(), git.Slugify(task.Title))
+
+	// Create execution record in DB.
+	exec, err := a.DB().CreateExecution(ctx, task.ID, task.ClusterID, crewID, baseBranch, execBranch, worktreePath)
+	if err != nil {
+		return nil, fmt.Errorf("engine: prepare: create execution: %w", err)
+	}
+
+	// Create git worktree with the new branch.
+	// Without a worktree the execution can never run, so drop its record
+	// rather than leave a pending execution behind.
+	if err := a.Repo().CreateWorktreeNewBranch(ctx, worktreePath, execBranch, baseBranch); err != nil {
+		err = fmt.Errorf("engine: prepare: create worktree: %w", err)
+		if derr := a.DB().DeleteExecution(context.Background(), exec.ID); derr != nil {
+			err = errors.Join(err, fmt.Errorf("engine: prepare: %w", derr))
+		}
+		return nil, err
+	}
+
+	// Update task status to running.
+	if err := a.DB().UpdateTaskStatus(ctx, task.ID, db.StatusRunning); err != nil {
+		return nil, fmt.Errorf("engine: prepare: update task status: %w", err)
+	}
+
+	return exec, nil
+}
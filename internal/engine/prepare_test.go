@@ -0,0 +1,47 @@
+//go:build unix
+
+package engine_test
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"bore-tui/internal/config"
+	"bore-tui/internal/db"
+	"bore-tui/internal/engine"
+)
+
+// TestPrepareRemovesExecutionWithoutWorktree checks that an execution whose
+// worktree cannot be created is not left behind.
+func TestPrepareRemovesExecutionWithoutWorktree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	t.Setenv("HOME", t.TempDir())
+
+	a := openCluster(t, filepath.Join(t.TempDir(), "repo"), func(*config.AgentsConfig) {})
+	ctx := context.Background()
+	d := a.DB()
+	clusterID := a.Cluster().ID
+	thread, err := d.CreateThread(ctx, clusterID, "Greetings", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := d.CreateTask(ctx, clusterID, thread.ID, "Add a greeting", "Add greeting.txt.", db.ComplexityBasic, db.ModeJustGetItDone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := engine.New(a).Prepare(ctx, task, "no-such-branch", nil); err == nil {
+		t.Fatal("prepare off a missing branch succeeded")
+	}
+	execs, err := d.ListExecutions(ctx, clusterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(execs) != 0 {
+		t.Errorf("executions = %+v, want none", execs)
+	}
+}
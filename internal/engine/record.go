@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"bore-tui/internal/agents"
+	"bore-tui/internal/db"
+)
+
+// recordBossPlan stores the structured form of a Boss plan. Failures are
+// reported but not fatal; the agent run still records a summary.
+func (e *Engine) recordBossPlan(r *run, ar *agentRun, plan agents.BossPlan) {
+	p := db.BossPlan{
+		ExecutionID:    r.execID,
+		AgentRunID:     agentRunRef(ar),
+		Validation:     plan.Validation,
+		EstimatedFiles: plan.EstimatedFiles,
+	}
+	for _, st := range plan.Steps {
+		p.Steps = append(p.Steps, db.PlanStep{
+			StepKey:    st.ID,
+			Title:      st.Title,
+			Detail:     st.Detail,
+			WorkerRole: st.WorkerRole,
+		})
+	}
+	if _, err := e.a.DB().CreateBossPlan(context.Background(), p); err != nil {
+		e.emit(r, fmt.Sprintf("Warning: could not record Boss plan: %v", err))
+	}
+}
+
+// recordWorkerResult stores the structured form of a worker result.
+// Failures are reported but not fatal.
+func (e *Engine) recordWorkerResult(r *run, ar *agentRun, role string, wr agents.WorkerResult) {
+	_, err := e.a.DB().CreateWorkerResult(context.Background(), db.WorkerResult{
+		ExecutionID:       r.execID,
+		AgentRunID:        agentRunRef(ar),
+		Role:              role,
+		Outcome:           wr.Outcome,
+		Summary:           wr.Summary,
+		FilesChanged:      wr.FilesChanged,
+		CommandsRun:       wr.CommandsRun,
+		ValidationResults: wr.ValidationResults,
+		Notes:             wr.Notes,
+		Blockers:          wr.Blockers,
+	})
+	if err != nil {
+		e.emit(r, fmt.Sprintf("Warning: could not record worker result: %v", err))
+	}
+}
+
+// workerResultFromRecord converts a stored worker result back into the form
+// the Boss prompts use.
+func workerResultFromRecord(wr *db.WorkerResult) *agents.WorkerResult {
+	return &agents.WorkerResult{
+		Type:              "worker_result",
+		Outcome:           wr.Outcome,
+		Summary:           wr.Summary,
+		FilesChanged:      wr.FilesChanged,
+		CommandsRun:       wr.CommandsRun,
+		ValidationResults: wr.ValidationResults,
+		Notes:             wr.Notes,
+		Blockers:          wr.Blockers,
+	}
+}
+
+// agentRunRef returns a reference to ar's agent_runs row, or nil if the row
+// could not be created.
+func agentRunRef(ar *agentRun) *int64 {
+	if ar.id == 0 {
+		return nil
+	}
+	id := ar.id
+	return &id
+}
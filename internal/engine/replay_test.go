@@ -0,0 +1,247 @@
+//go:build unix
+
+package engine_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"bore-tui/internal/app"
+	"bore-tui/internal/config"
+	"bore-tui/internal/db"
+	"bore-tui/internal/engine"
+)
+
+// scriptedAgent answers each agent prompt with a fixed response, picked by
+// a marker from the prompt: the Boss plans one alpha worker, asks for a
+// beta worker on review and then summarizes; each worker writes a file.
+const scriptedAgent = `#!/bin/sh
+prompt=$(cat)
+case "$prompt" in
+*"Review all worker results above against the plan"*)
+	echo '{"type":"spawn_workers","workers":[{"role":"beta","goal":"Write beta.txt"}]}' ;;
+*"Produce a final summary of the execution"*)
+	echo '{"type":"boss_summary","outcome":"success","what_changed":["Added alpha.txt and beta.txt"],"lessons":[{"lesson_type":"note","content":"Greetings go in text files"}]}' ;;
+*"Create a step-by-step plan"*)
+	echo '{"type":"boss_plan","steps":[{"id":"s1","title":"Write alpha.txt","worker_role":"alpha"}],"needs_workers":[{"role":"alpha","goal":"Write alpha.txt"}]}' ;;
+*"**Role**: alpha"*)
+	echo alpha > alpha.txt
+	echo '{"type":"worker_result","outcome":"success","summary":"Wrote alpha.txt","files_changed":["alpha.txt"]}' ;;
+*"**Role**: beta"*)
+	echo beta > beta.txt
+	echo '{"type":"worker_result","outcome":"success","summary":"Wrote beta.txt","files_changed":["beta.txt"]}' ;;
+*)
+	echo "unexpected prompt" >&2
+	exit 1 ;;
+esac
+`
+
+// TestReplayExecution records a Boss plan, worker, Boss review, worker and
+// summary run from a scripted agent, then replays the recording into a
+// fresh repository and checks the execution ends the same way.
+func TestReplayExecution(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	t.Setenv("HOME", t.TempDir())
+
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	script := filepath.Join(root, "agent.sh")
+	if err := os.WriteFile(script, []byte(scriptedAgent), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Record. Both runs use the same repository path and a fresh database,
+	// so the prompts, and with them the recordings' hashes, match.
+	_, recorded := runExecution(t, repo, func(ac *config.AgentsConfig) {
+		ac.Backends = map[string]config.BackendConfig{
+			"scripted": {Type: config.BackendTypeCommand, Command: []string{"/bin/sh", script}},
+		}
+		ac.RoleBackends = map[string]string{config.RoleBoss: "scripted", config.RoleWorker: "scripted"}
+		ac.RecordRuns = true
+	})
+	if recorded.Status != db.StatusDiffReview {
+		t.Fatalf("recorded execution status = %q, want %q", recorded.Status, db.StatusDiffReview)
+	}
+	sessions, err := filepath.Glob(filepath.Join(repo, ".bore", "runs", "*"))
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("recording sessions = %v (%v), want one", sessions, err)
+	}
+	recordings := filepath.Join(root, "recordings")
+	if err := os.Rename(sessions[0], recordings); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replay without the script.
+	if err := os.Remove(script); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(repo); err != nil {
+		t.Fatal(err)
+	}
+	a, ex := runExecution(t, repo, func(ac *config.AgentsConfig) {
+		ac.Backends = map[string]config.BackendConfig{
+			"replay": {Type: config.BackendTypeReplay, Dir: recordings},
+		}
+		ac.RoleBackends = map[string]string{config.RoleBoss: "replay", config.RoleWorker: "replay"}
+	})
+
+	ctx := context.Background()
+	d := a.DB()
+	if ex.Status != db.StatusDiffReview {
+		t.Fatalf("execution status = %q, want %q", ex.Status, db.StatusDiffReview)
+	}
+
+	runs, err := d.GetAgentRuns(ctx, ex.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var roles []string
+	for _, r := range runs {
+		roles = append(roles, r.Role)
+		if r.Outcome != db.OutcomeSuccess {
+			t.Errorf("agent run %s outcome = %q, want %q", r.Role, r.Outcome, db.OutcomeSuccess)
+		}
+	}
+	if want := []string{"planner", "alpha", "reviewer", "beta", "summarizer"}; !slices.Equal(roles, want) {
+		t.Errorf("agent run roles = %v, want %v", roles, want)
+	}
+
+	plans, err := d.ListBossPlans(ctx, ex.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plans) != 1 || len(plans[0].Steps) != 1 {
+		t.Errorf("boss plans = %+v, want one with one step", plans)
+	}
+
+	results, err := d.ListWorkerResults(ctx, ex.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summaries []string
+	for _, r := range results {
+		summaries = append(summaries, r.Summary)
+	}
+	if want := []string{"Wrote alpha.txt", "Wrote beta.txt"}; !slices.Equal(summaries, want) {
+		t.Errorf("worker result summaries = %v, want %v", summaries, want)
+	}
+
+	lessons, err := d.ListLessons(ctx, ex.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lessons) != 1 || lessons[0].Content != "Greetings go in text files" {
+		t.Errorf("lessons = %+v, want the summary's one lesson", lessons)
+	}
+
+	for _, name := range []string{"alpha", "beta"} {
+		data, err := os.ReadFile(filepath.Join(ex.WorktreePath, name+".txt"))
+		if err != nil {
+			t.Errorf("worktree: %v", err)
+		} else if string(data) != name+"\n" {
+			t.Errorf("%s.txt = %q, want %q", name, data, name+"\n")
+		}
+	}
+}
+
+// runExecution creates a git repository at repo, opens it as a cluster with
+// the agent settings applied by configure, runs one task to completion and
+// returns the app and the finished execution. The app is closed when the
+// test ends.
+func runExecution(t *testing.T, repo string, configure func(*config.AgentsConfig)) (*app.App, *db.Execution) {
+	t.Helper()
+	a := openCluster(t, repo, configure)
+	return a, runTask(t, a, nil)
+}
+
+// openCluster creates a git repository at repo and opens it as a cluster
+// with the agent settings applied by configure. The app is closed when the
+// test ends.
+func openCluster(t *testing.T, repo string, configure func(*config.AgentsConfig)) *app.App {
+	t.Helper()
+	ctx := context.Background()
+
+	if err := os.MkdirAll(repo, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "README.md"), []byte("# test\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git(t, repo, "init", "-q", "-b", "main")
+	git(t, repo, "add", "README.md")
+	git(t, repo, "commit", "-q", "-m", "Initial commit")
+
+	a := app.New()
+	t.Cleanup(func() { a.Close() })
+	if err := a.InitCluster(ctx, repo); err != nil {
+		t.Fatalf("init cluster: %v", err)
+	}
+	cfgPath := filepath.Join(repo, ".bore", "config.json")
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Logging.ToConsole = false
+	cfg.Agents.MaxBossRounds = 1
+	configure(&cfg.Agents)
+	if err := config.Save(cfg, cfgPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.OpenCluster(ctx, repo); err != nil {
+		t.Fatalf("open cluster: %v", err)
+	}
+	return a
+}
+
+// runTask creates a task, runs it to completion, with the crew crewID if
+// not nil, and returns the finished execution.
+func runTask(t *testing.T, a *app.App, crewID *int64) *db.Execution {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	d := a.DB()
+	clusterID := a.Cluster().ID
+	thread, err := d.CreateThread(ctx, clusterID, "Greetings", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := d.CreateTask(ctx, clusterID, thread.ID, "Add greeting files", "Add alpha.txt and beta.txt.", db.ComplexityBasic, db.ModeJustGetItDone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eng := engine.New(a)
+	ex, err := eng.Prepare(ctx, task, "main", crewID)
+	if err != nil {
+		t.Fatalf("prepare: %v", err)
+	}
+	if err := eng.Start(ex.ID); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if err := eng.Wait(ctx, ex.ID); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	ex, err = d.GetExecution(ctx, ex.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ex
+}
+
+// git runs a git command in dir with a fixed identity.
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=bore", "-c", "user.email=bore@example.com"}, args...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
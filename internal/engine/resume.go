@@ -0,0 +1,242 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"bore-tui/internal/agents"
+	"bore-tui/internal/db"
+)
+
+// plannedWorker is one worker spawned by the Boss, persisted in spawn order
+// in execution_plans.workers_json. AgentRunID is set once the worker has
+// produced a result; Resume skips the worker if that result was a success.
+type plannedWorker struct {
+	Need       agents.WorkerNeed `json:"need"`
+	AgentRunID int64             `json:"agent_run_id,omitempty"`
+
+	result *agents.WorkerResult
+}
+
+// resumeState is the progress of an interrupted execution, reloaded from the
+// database by Resume.
+type resumeState struct {
+	brief    agents.ExecutionBrief
+	plan     *agents.BossPlan // nil if the execution never got a plan saved
+	workers  []plannedWorker
+	guidance []string
+}
+
+// Resume continues an interrupted or cancelled execution in its existing
+// worktree. The persisted Boss plan is reused and workers that already
+// succeeded are skipped; the remaining workers, including those that failed
+// or only partly succeeded, run again and the Boss summary is re-run. An execution stopped before its plan was saved starts
+// over from the Boss plan phase.
+func (e *Engine) Resume(execID int64) error {
+	if e.a.DB() == nil {
+		return fmt.Errorf("engine: resume: no cluster open")
+	}
+
+	bg := context.Background()
+	exec, err := e.a.DB().GetExecution(bg, execID)
+	if err != nil {
+		return fmt.Errorf("engine: resume: load execution %d: %w", execID, err)
+	}
+	if exec == nil {
+		return fmt.Errorf("engine: resume: execution %d not found", execID)
+	}
+	if exec.Status != db.StatusInterrupted && exec.Status != db.StatusCancelled {
+		return fmt.Errorf("engine: resume: execution %d is %s, not interrupted or cancelled", execID, exec.Status)
+	}
+	if _, err := os.Stat(exec.WorktreePath); err != nil {
+		return fmt.Errorf("engine: resume: worktree for execution %d is gone: %w", execID, err)
+	}
+
+	rs, err := e.loadResumeState(bg, exec)
+	if err != nil {
+		return fmt.Errorf("engine: resume: %w", err)
+	}
+	return e.launch(exec, func(ctx context.Context, r *run) string {
+		return e.execute(ctx, r, exec, nil, rs)
+	})
+}
+
+// loadResumeState reloads the persisted plan, the results of workers that
+// succeeded and the user's answers for an interrupted execution. Any other
+// worker is left without a result so that it runs again.
+func (e *Engine) loadResumeState(ctx context.Context, exec *db.Execution) (*resumeState, error) {
+	d := e.a.DB()
+	rs := &resumeState{}
+
+	questions, err := d.ListQuestions(ctx, exec.ID)
+	if err != nil {
+		return nil, fmt.Errorf("load questions: %w", err)
+	}
+	for _, q := range questions {
+		if q.Status == db.QuestionAnswered && q.Answer != "" {
+			rs.guidance = append(rs.guidance, formatGuidance(q.AgentRole, q.Question, q.Answer))
+		}
+	}
+
+	stored, err := d.GetExecutionPlan(ctx, exec.ID)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return rs, nil
+	}
+
+	if stored.BriefJSON != "" {
+		if err := json.Unmarshal([]byte(stored.BriefJSON), &rs.brief); err != nil {
+			return nil, fmt.Errorf("decode stored brief: %w", err)
+		}
+	}
+	var plan agents.BossPlan
+	if err := json.Unmarshal([]byte(stored.PlanJSON), &plan); err != nil {
+		return nil, fmt.Errorf("decode stored plan: %w", err)
+	}
+	rs.plan = &plan
+	if err := json.Unmarshal([]byte(stored.WorkersJSON), &rs.workers); err != nil {
+		return nil, fmt.Errorf("decode stored workers: %w", err)
+	}
+
+	runs, err := d.GetAgentRuns(ctx, exec.ID)
+	if err != nil {
+		return nil, fmt.Errorf("load agent runs: %w", err)
+	}
+	byID := make(map[int64]db.AgentRun, len(runs))
+	for _, ar := range runs {
+		byID[ar.ID] = ar
+	}
+	for i := range rs.workers {
+		ar, ok := byID[rs.workers[i].AgentRunID]
+		if !ok || ar.Outcome != db.OutcomeSuccess {
+			rs.workers[i].AgentRunID = 0
+			continue
+		}
+		recorded, err := d.GetWorkerResultByAgentRun(ctx, ar.ID)
+		if err != nil {
+			return nil, fmt.Errorf("load worker result: %w", err)
+		}
+		wr := workerResultFromRun(ar)
+		if recorded != nil {
+			wr = workerResultFromRecord(recorded)
+		}
+		if wr.Outcome != db.OutcomeSuccess {
+			// Downgraded after the run, e.g. for changes outside the
+			// crew's ownership paths.
+			rs.workers[i].AgentRunID = 0
+			continue
+		}
+		rs.workers[i].result = wr
+	}
+	return rs, nil
+}
+
+// workerResultFromRun rebuilds the parts of a WorkerResult the Boss needs
+// from an agent run recorded without a structured result.
+func workerResultFromRun(ar db.AgentRun) *agents.WorkerResult {
+	wr := &agents.WorkerResult{
+		Type:    "worker_result",
+		Outcome: ar.Outcome,
+		Summary: ar.Summary,
+	}
+	if ar.FilesChanged != "" {
+		wr.FilesChanged = strings.Split(ar.FilesChanged, ", ")
+	}
+	return wr
+}
+
+// savePlan persists the brief and Boss plan of a fresh execution so that it
+// can be resumed. Failures are reported but not fatal.
+func (e *Engine) savePlan(r *run, brief agents.ExecutionBrief, plan *agents.BossPlan) {
+	briefJSON, err := json.Marshal(brief)
+	if err == nil {
+		var planJSON []byte
+		planJSON, err = json.Marshal(plan)
+		if err == nil {
+			err = e.a.DB().SaveExecutionPlan(context.Background(), r.execID, string(briefJSON), string(planJSON), "[]")
+		}
+	}
+	if err != nil {
+		e.emit(r, fmt.Sprintf("Warning: could not save Boss plan; this execution cannot be resumed: %v", err))
+	}
+}
+
+// spawnWorkers appends needs to the run's worker list, persists it and
+// returns the indices of the new workers.
+func (e *Engine) spawnWorkers(r *run, needs []agents.WorkerNeed) []int {
+	r.planMu.Lock()
+	defer r.planMu.Unlock()
+	idx := make([]int, len(needs))
+	for i, need := range needs {
+		idx[i] = len(r.workers)
+		r.workers = append(r.workers, plannedWorker{Need: need})
+	}
+	e.persistWorkers(r)
+	return idx
+}
+
+// completeWorker records the result of worker i and persists it.
+func (e *Engine) completeWorker(r *run, i int, agentRunID int64, wr *agents.WorkerResult) {
+	r.planMu.Lock()
+	defer r.planMu.Unlock()
+	r.workers[i].AgentRunID = agentRunID
+	r.workers[i].result = wr
+	e.persistWorkers(r)
+}
+
+// persistWorkers writes the run's worker list to execution_plans. r.planMu
+// must be held so concurrent workers cannot write stale lists out of order.
+func (e *Engine) persistWorkers(r *run) {
+	data, err := json.Marshal(r.workers)
+	if err == nil {
+		err = e.a.DB().UpdateExecutionPlanWorkers(context.Background(), r.execID, string(data))
+	}
+	if err != nil {
+		e.emit(r, fmt.Sprintf("Warning: could not save worker progress: %v", err))
+	}
+}
+
+// pendingWorkers returns the indices of workers without a result.
+func (r *run) pendingWorkers() []int {
+	r.planMu.Lock()
+	defer r.planMu.Unlock()
+	var idx []int
+	for i, w := range r.workers {
+		if w.result == nil {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// workerResults returns the results of all completed workers in spawn order.
+func (r *run) workerResults() []agents.WorkerResult {
+	r.planMu.Lock()
+	defer r.planMu.Unlock()
+	var out []agents.WorkerResult
+	for _, w := range r.workers {
+		if w.result != nil {
+			out = append(out, *w.result)
+		}
+	}
+	return out
+}
+
+// workerNeed returns the need of worker i.
+func (r *run) workerNeed(i int) agents.WorkerNeed {
+	r.planMu.Lock()
+	defer r.planMu.Unlock()
+	return r.workers[i].Need
+}
+
+// spawnedCount returns the number of workers spawned so far.
+func (r *run) spawnedCount() int {
+	r.planMu.Lock()
+	defer r.planMu.Unlock()
+	return len(r.workers)
+}
@@ -0,0 +1,116 @@
+//go:build unix
+
+package engine_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bore-tui/internal/config"
+	"bore-tui/internal/db"
+	"bore-tui/internal/engine"
+)
+
+// flakyAgent plans an alpha and a beta worker and summarizes. alpha always
+// succeeds; beta fails until the file named by $BETA_OK exists.
+const flakyAgent = `#!/bin/sh
+prompt=$(cat)
+case "$prompt" in
+*"Produce a final summary of the execution"*)
+	echo '{"type":"boss_summary","outcome":"success","what_changed":["Added alpha.txt and beta.txt"]}' ;;
+*"Create a step-by-step plan"*)
+	echo '{"type":"boss_plan","steps":[{"id":"s1","title":"Write both files"}],"needs_workers":[{"role":"alpha","goal":"Write alpha.txt"},{"role":"beta","goal":"Write beta.txt"}]}' ;;
+*"**Role**: alpha"*)
+	echo alpha > alpha.txt
+	echo '{"type":"worker_result","outcome":"success","summary":"Wrote alpha.txt"}' ;;
+*"**Role**: beta"*)
+	if [ ! -e "$BETA_OK" ]; then
+		echo '{"type":"worker_result","outcome":"failed","summary":"Could not write beta.txt"}'
+		exit 0
+	fi
+	echo beta > beta.txt
+	echo '{"type":"worker_result","outcome":"success","summary":"Wrote beta.txt"}' ;;
+*)
+	echo "unexpected prompt" >&2
+	exit 1 ;;
+esac
+`
+
+// TestResumeRerunsFailedWorkers interrupts an execution in which one of two
+// workers failed and checks that resuming it runs only the failed worker
+// again.
+func TestResumeRerunsFailedWorkers(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	t.Setenv("HOME", t.TempDir())
+
+	root := t.TempDir()
+	script := filepath.Join(root, "agent.sh")
+	if err := os.WriteFile(script, []byte(flakyAgent), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	betaOK := filepath.Join(root, "beta-ok")
+	t.Setenv("BETA_OK", betaOK)
+
+	a, ex := runExecution(t, filepath.Join(root, "repo"), func(ac *config.AgentsConfig) {
+		ac.Backends = map[string]config.BackendConfig{
+			"scripted": {Type: config.BackendTypeCommand, Command: []string{"/bin/sh", script}},
+		}
+		ac.RoleBackends = map[string]string{config.RoleBoss: "scripted", config.RoleWorker: "scripted"}
+		ac.MaxBossRounds = 0
+	})
+
+	// Pretend the process running the execution died after the summary.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	d := a.DB()
+	if err := d.UpdateExecutionStatus(ctx, ex.ID, db.StatusInterrupted); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(betaOK, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	eng := engine.New(a)
+	if err := eng.Resume(ex.ID); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if err := eng.Wait(ctx, ex.ID); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+
+	ex, err := d.GetExecution(ctx, ex.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ex.Status != db.StatusDiffReview {
+		t.Fatalf("execution status = %q, want %q", ex.Status, db.StatusDiffReview)
+	}
+
+	runs, err := d.GetAgentRuns(ctx, ex.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outcomes := make(map[string][]string)
+	for _, r := range runs {
+		outcomes[r.Role] = append(outcomes[r.Role], r.Outcome)
+	}
+	if got := outcomes["alpha"]; len(got) != 1 || got[0] != db.OutcomeSuccess {
+		t.Errorf("alpha runs = %v, want one success", got)
+	}
+	if got := outcomes["beta"]; len(got) != 2 || got[0] != db.OutcomeFailed || got[1] != db.OutcomeSuccess {
+		t.Errorf("beta runs = %v, want a failure, then a success on resume", got)
+	}
+	if got := outcomes["summarizer"]; len(got) != 2 {
+		t.Errorf("summarizer runs = %v, want one per run", got)
+	}
+
+	if _, err := os.Stat(filepath.Join(ex.WorktreePath, "beta.txt")); err != nil {
+		t.Errorf("worktree: %v", err)
+	}
+}
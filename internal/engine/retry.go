@@ -0,0 +1,162 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"bore-tui/internal/agents"
+	"bore-tui/internal/config"
+	"bore-tui/internal/db"
+	"bore-tui/internal/process"
+)
+
+// maxRetryDetail bounds the failure detail quoted in a retry event.
+const maxRetryDetail = 200
+
+// runAgent runs prompt on the backend for role and parses its response,
+// retrying failed attempts as agents.retry allows for the failure's class.
+// A response that is missing or breaks its schema is first sent back to the
+// agent for correction, up to agents.repair_attempts times, before the
+// malformed retry policy reruns the prompt. Each retry and repair is
+// recorded as an event. The usage of every attempt is charged to ar.
+//
+// r and ar are nil for an agent that runs outside an execution, such as the
+// Commander: it is retried the same way, but nothing is recorded.
+//
+// It returns the last attempt's result, its parsed response, and the parse
+// error if the response could not be parsed. Callers check result.Err and
+// result.JSONBlock first, as for a single run.
+func (e *Engine) runAgent(ctx context.Context, r *run, ar *agentRun, role, workDir, prompt string, opts process.RunOptions) (*process.RunResult, any, error) {
+	var policies config.RetryConfig
+	var maxRepairs int
+	if cfg := e.a.Config(); cfg != nil {
+		policies = cfg.Agents.Retry
+		maxRepairs = cfg.Agents.RepairAttempts
+	}
+	label := role
+	if ar != nil {
+		label = ar.label
+	}
+	retried := make(map[process.Failure]int)
+	repairs := 0
+	runPrompt, runOpts := prompt, opts
+	for {
+		result := e.a.Backend(role).RunWithOptions(ctx, workDir, runPrompt, runOpts)
+		if ar != nil {
+			e.recordUsage(r, ar, result)
+		}
+
+		var parsed any
+		var parseErr error
+		class := process.Classify(result)
+		if class == process.FailureNone {
+			if result.JSONBlock == "" {
+				class = process.FailureMalformed
+			} else if parsed, parseErr = agents.ParseResponse(result.JSONBlock); parseErr != nil {
+				class = process.FailureMalformed
+			}
+		}
+		if class == process.FailureNone || ctx.Err() != nil {
+			return result, parsed, parseErr
+		}
+
+		detail := failureDetail(result, parseErr)
+		if class == process.FailureMalformed && repairs < maxRepairs && !r.overBudgetNow() {
+			repairs++
+			msg := fmt.Sprintf("%s response rejected (%s). Asking for a corrected response, attempt %d of %d",
+				label, detail, repairs, maxRepairs)
+			e.noteRetry(r, db.LevelWarn, "agent_repair", msg)
+			runPrompt = agents.BuildRepairPrompt(prompt, rejectedResponse(result), repairProblem(parseErr))
+			runOpts.Permissions = process.ReadOnly()
+			continue
+		}
+
+		policy := policies.For(string(class))
+		n := retried[class] + 1
+		if n > policy.Retries || r.overBudgetNow() {
+			if len(retried) > 0 || repairs > 0 {
+				if r != nil {
+					msg := fmt.Sprintf("%s failed (%s) after retrying: %s", label, class, detail)
+					_ = e.a.DB().CreateEvent(context.Background(), r.execID, db.LevelError, "agent_retries_exhausted", msg)
+				}
+			}
+			return result, parsed, parseErr
+		}
+		retried[class] = n
+		runPrompt, runOpts = prompt, opts
+
+		delay := backoff(policy, n)
+		msg := fmt.Sprintf("%s failed (%s): %s. Retry %d of %d in %s",
+			label, class, detail, n, policy.Retries, delay.Round(100*time.Millisecond))
+		e.noteRetry(r, db.LevelWarn, "agent_retry", msg)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return result, parsed, parseErr
+		}
+	}
+}
+
+// noteRetry records a retry or repair of one of r's agents as an event and
+// in its output. It does nothing for an agent outside an execution.
+func (e *Engine) noteRetry(r *run, level, eventType, msg string) {
+	if r == nil {
+		return
+	}
+	_ = e.a.DB().CreateEvent(context.Background(), r.execID, level, eventType, msg)
+	e.emit(r, msg+"...")
+}
+
+// rejectedResponse returns what the agent answered with: its JSON if any,
+// otherwise its final text.
+func rejectedResponse(result *process.RunResult) string {
+	if result.JSONBlock != "" {
+		return result.JSONBlock
+	}
+	return result.Text
+}
+
+// repairProblem explains to the agent why its response was rejected; a nil
+// parse error means the response held no JSON at all.
+func repairProblem(parseErr error) error {
+	if parseErr != nil {
+		return parseErr
+	}
+	return errors.New("no JSON object was found in your response")
+}
+
+// failureDetail describes why an attempt failed in one short line.
+func failureDetail(result *process.RunResult, parseErr error) string {
+	var s string
+	switch {
+	case result.Err != nil:
+		s = result.Err.Error()
+	case result.JSONBlock == "":
+		s = "no JSON response"
+	case parseErr != nil:
+		s = "parse error: " + parseErr.Error()
+	default:
+		s = "error result: " + result.Text
+	}
+	s = strings.Join(strings.Fields(s), " ")
+	if len(s) > maxRetryDetail {
+		s = s[:maxRetryDetail] + "…"
+	}
+	return s
+}
+
+// backoff returns the wait before retry n (1-based) under p: exponential
+// from BaseDelaySec, capped at MaxDelaySec if set, with ±Jitter applied.
+func backoff(p config.RetryPolicy, n int) time.Duration {
+	d := p.BaseDelaySec * math.Pow(2, float64(n-1))
+	if p.MaxDelaySec > 0 && d > p.MaxDelaySec {
+		d = p.MaxDelaySec
+	}
+	d *= 1 + p.Jitter*(2*rand.Float64()-1)
+	return time.Duration(d * float64(time.Second))
+}
@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"bore-tui/internal/db"
+)
+
+// Merge commits everything in a finished execution's worktree, merges its
+// branch into the base branch, removes the worktree and branch, and marks the
+// execution and its task completed. It returns the branch merged into.
+func (e *Engine) Merge(ctx context.Context, execID int64) (string, error) {
+	exec, err := e.reviewable(ctx, "merge", execID)
+	if err != nil {
+		return "", err
+	}
+	repo, d := e.a.Repo(), e.a.DB()
+
+	if err := repo.AddAll(ctx, exec.WorktreePath); err != nil {
+		return "", fmt.Errorf("engine: merge: git add: %w", err)
+	}
+	commitMsg := fmt.Sprintf("bore-tui: execution #%d", exec.ID)
+	if err := repo.Commit(ctx, exec.WorktreePath, commitMsg); err != nil {
+		return "", fmt.Errorf("engine: merge: git commit: %w", err)
+	}
+
+	baseBranch := exec.BaseBranch
+	if baseBranch == "" {
+		baseBranch = "main"
+	}
+	if err := repo.MergeInto(ctx, baseBranch, exec.ExecBranch); err != nil {
+		return "", fmt.Errorf("engine: merge: git merge: %w", err)
+	}
+
+	if err := repo.RemoveWorktree(ctx, exec.WorktreePath); err != nil {
+		return "", fmt.Errorf("engine: merge: remove worktree: %w", err)
+	}
+	_ = repo.DeleteBranch(ctx, exec.ExecBranch) // best-effort
+	_ = repo.PruneWorktrees(ctx)
+
+	_ = d.UpdateExecutionStatus(ctx, exec.ID, db.StatusCompleted)
+	_ = d.UpdateTaskStatus(ctx, exec.TaskID, db.StatusCompleted)
+	return baseBranch, nil
+}
+
+// Revert discards every change in an execution's worktree and marks the
+// execution and its task interrupted. The worktree itself is kept.
+func (e *Engine) Revert(ctx context.Context, execID int64) error {
+	exec, err := e.reviewable(ctx, "revert", execID)
+	if err != nil {
+		return err
+	}
+	if err := e.a.Repo().Revert(ctx, exec.WorktreePath, true); err != nil {
+		return fmt.Errorf("engine: revert: git revert: %w", err)
+	}
+	_ = e.a.DB().SetExecutionFinished(ctx, exec.ID, db.StatusInterrupted)
+	_ = e.a.DB().UpdateTaskStatus(ctx, exec.TaskID, db.StatusInterrupted)
+	return nil
+}
+
+// reviewable loads an execution whose worktree may be merged or reverted:
+// one that is not running, in this engine or in another bore-tui process.
+func (e *Engine) reviewable(ctx context.Context, op string, execID int64) (*db.Execution, error) {
+	if e.a.DB() == nil || e.a.Repo() == nil {
+		return nil, fmt.Errorf("engine: %s: no cluster open", op)
+	}
+	exec, err := e.a.DB().GetExecution(ctx, execID)
+	if err != nil {
+		return nil, fmt.Errorf("engine: %s: load execution %d: %w", op, execID, err)
+	}
+	if exec == nil {
+		return nil, fmt.Errorf("engine: %s: execution %d not found", op, execID)
+	}
+	if e.Running(execID) || exec.Status == db.StatusRunning || exec.Status == db.StatusAwaitingUser {
+		return nil, fmt.Errorf("engine: %s: execution %d is still running", op, execID)
+	}
+	return exec, nil
+}
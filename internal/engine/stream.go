@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"bore-tui/internal/db"
+	"bore-tui/internal/logging"
+	"bore-tui/internal/process"
+)
+
+// agentRun is an agent invocation whose output is being streamed.
+type agentRun struct {
+	id      int64 // agent_runs row ID; 0 if the row could not be created
+	label   string
+	log     *logging.Logger // per-agent log file; nil if unavailable
+	started time.Time
+	usage   db.Usage // summed over every attempt
+}
+
+// beginAgentRun records an in-progress agent run and opens its log file.
+// Failures are non-fatal: the agent still runs, just without a log or row.
+func (e *Engine) beginAgentRun(r *run, execID int64, agentType, role, prompt string) *agentRun {
+	ar := &agentRun{label: role, started: time.Now()}
+	row, err := e.a.DB().CreateAgentRun(context.Background(), execID, agentType, role,
+		prompt, "", db.OutcomeRunning, "")
+	if err != nil {
+		e.emit(r, "Warning: could not record agent run: "+err.Error())
+		return ar
+	}
+	ar.id = row.ID
+
+	r.mu.Lock()
+	r.active[ar.id] = true
+	r.mu.Unlock()
+
+	if logs := e.a.Logs(); logs != nil {
+		if l, err := logs.WorkerLogger(ar.id); err == nil {
+			ar.log = l
+			l.Info("%s %s started (execution %d)", agentType, role, execID)
+		}
+	}
+	e.publish(Event{ExecutionID: execID, Kind: EventAgentStarted, Agent: role, AgentRunID: ar.id})
+	return ar
+}
+
+// finishAgentRun records the agent's final summary and outcome.
+func (e *Engine) finishAgentRun(r *run, ar *agentRun, summary, outcome, filesChanged string) {
+	if ar.id == 0 {
+		return
+	}
+	r.mu.Lock()
+	delete(r.active, ar.id)
+	stopped := r.stopped != ""
+	r.mu.Unlock()
+
+	if stopped && outcome == db.OutcomeFailed {
+		// The agent was killed by Cancel or Shutdown; leave the row
+		// running so markCancelled records the reason instead of the
+		// kill error.
+		if ar.log != nil {
+			ar.log.Warn("%s stopped: %s", ar.label, summary)
+			_ = e.a.Logs().CloseWorkerLogger(ar.id)
+		}
+		return
+	}
+
+	if ar.log != nil {
+		ar.log.Info("%s finished: %s", ar.label, outcome)
+		_ = e.a.Logs().CloseWorkerLogger(ar.id)
+	}
+	_ = e.a.DB().UpdateAgentRun(context.Background(), ar.id, summary, outcome, filesChanged)
+}
+
+// recordUsage adds the token usage and cost of an attempt at an agent's run
+// to its totals and stores them with the model and wall-clock duration so
+// far, then checks the execution against its budget.
+func (e *Engine) recordUsage(r *run, ar *agentRun, result *process.RunResult) {
+	if ar.id == 0 {
+		return
+	}
+	u := &ar.usage
+	u.CostUSD += result.CostUSD
+	u.DurationMS = time.Since(ar.started).Milliseconds()
+	if result.Usage != nil {
+		u.InputTokens += int64(result.Usage.InputTokens)
+		u.OutputTokens += int64(result.Usage.OutputTokens)
+		u.CacheCreationTokens += int64(result.Usage.CacheCreationInputTokens)
+		u.CacheReadTokens += int64(result.Usage.CacheReadInputTokens)
+	}
+	_ = e.a.DB().SetAgentRunUsage(context.Background(), ar.id, result.Model, *u)
+	e.checkBudget(r)
+}
+
+// streamOutput returns Runner callbacks that write each stdout/stderr line
+// of an agent to its log file and publish it to subscribers.
+func (e *Engine) streamOutput(r *run, ar *agentRun) (onStdout, onStderr func(string)) {
+	onStdout = func(line string) {
+		if ar.log != nil {
+			ar.log.Info("%s", line)
+		}
+		e.emitAgent(r, ar, line)
+	}
+	onStderr = func(line string) {
+		if ar.log != nil {
+			ar.log.Warn("%s", line)
+		}
+		e.emitAgent(r, ar, "stderr: "+line)
+	}
+	return onStdout, onStderr
+}
+
+// emitAgent buffers and publishes one line of raw agent output.
+func (e *Engine) emitAgent(r *run, ar *agentRun, line string) {
+	r.mu.Lock()
+	r.appendOutput("[" + ar.label + "] " + line)
+	r.mu.Unlock()
+	e.publish(Event{ExecutionID: r.execID, Kind: EventAgentOutput, Agent: ar.label, AgentRunID: ar.id, Message: line})
+}
@@ -3,6 +3,7 @@ package git
 import (
 	"context"
 	"fmt"
+	"strings"
 )
 
 // AddAll stages all changes (new, modified, deleted) in the working tree at dir.
@@ -39,6 +40,46 @@ func (r *Repo) Revert(ctx context.Context, dir string, force bool) error {
 	return err
 }
 
+// DiscardPaths restores the given paths, relative to dir, in the working
+// tree at dir to their state in HEAD. Paths that are not in HEAD are
+// unstaged and deleted.
+func (r *Repo) DiscardPaths(ctx context.Context, dir string, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	out, err := r.runInDir(ctx, dir, append([]string{"ls-tree", "-r", "-z", "--name-only", "HEAD", "--"}, paths...)...)
+	if err != nil {
+		return err
+	}
+	inHead := make(map[string]bool)
+	for _, p := range strings.Split(out, "\x00") {
+		inHead[p] = true
+	}
+	var tracked, added []string
+	for _, p := range paths {
+		if inHead[p] {
+			tracked = append(tracked, p)
+		} else {
+			added = append(added, p)
+		}
+	}
+
+	if len(tracked) > 0 {
+		if _, err := r.runInDir(ctx, dir, append([]string{"checkout", "HEAD", "--"}, tracked...)...); err != nil {
+			return err
+		}
+	}
+	if len(added) > 0 {
+		if _, err := r.runInDir(ctx, dir, append([]string{"rm", "-q", "--cached", "--ignore-unmatch", "--"}, added...)...); err != nil {
+			return err
+		}
+		if _, err := r.runInDir(ctx, dir, append([]string{"clean", "-f", "-q", "--"}, added...)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetCommitLog returns the most recent commits (one per line) from the
 // working tree at dir.
 func (r *Repo) GetCommitLog(ctx context.Context, dir string, count int) (string, error) {
@@ -1,6 +1,9 @@
 package git
 
-import "context"
+import (
+	"context"
+	"strings"
+)
 
 // Status returns the short-format status of the working tree at dir.
 func (r *Repo) Status(ctx context.Context, dir string) (string, error) {
@@ -49,3 +52,24 @@ func (r *Repo) HasChanges(ctx context.Context, dir string) (bool, error) {
 	}
 	return out != "", nil
 }
+
+// ChangedFiles returns the paths, relative to dir, of every file in the
+// working tree at dir that differs from HEAD: modified, added, deleted or
+// untracked (ignored files excluded).
+func (r *Repo) ChangedFiles(ctx context.Context, dir string) ([]string, error) {
+	tracked, err := r.runInDir(ctx, dir, "diff", "--name-only", "--no-renames", "-z", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := r.runInDir(ctx, dir, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, p := range strings.Split(tracked+"\x00"+untracked, "\x00") {
+		if p != "" {
+			files = append(files, p)
+		}
+	}
+	return files, nil
+}
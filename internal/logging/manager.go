@@ -100,6 +100,21 @@ func (m *Manager) WorkerLogger(agentRunID int64) (*Logger, error) {
 	return l, nil
 }
 
+// CloseWorkerLogger closes and forgets the logger for agentRunID, if one is
+// open. A later WorkerLogger call for the same ID reopens the file in append
+// mode.
+func (m *Manager) CloseWorkerLogger(agentRunID int64) error {
+	m.mu.Lock()
+	l, ok := m.workers[agentRunID]
+	delete(m.workers, agentRunID)
+	m.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+	return l.Close()
+}
+
 // EnsureRunDir creates the directory .bore/runs/{executionID}/ if it does
 // not already exist and returns its absolute path.
 func (m *Manager) EnsureRunDir(executionID int64) (string, error) {
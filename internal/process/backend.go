@@ -0,0 +1,83 @@
+package process
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// AgentBackend runs one agent prompt in a working directory. It blocks
+// until the agent finishes, streaming output through the callbacks in
+// opts, and returns the final text, the JSON block extracted from it and
+// any usage the backend reports.
+//
+// The Claude CLI Runner is the reference implementation. Backends that
+// cannot enforce opts.Permissions say so in their documentation.
+type AgentBackend interface {
+	RunWithOptions(ctx context.Context, workDir string, prompt string, opts RunOptions) *RunResult
+}
+
+// Placeholders substituted in a CommandBackend's argument template.
+const (
+	PlaceholderPrompt  = "{prompt}"  // the full prompt
+	PlaceholderModel   = "{model}"   // the configured model
+	PlaceholderWorkDir = "{workdir}" // the working directory
+)
+
+// CommandBackend runs an arbitrary coding-agent CLI from an argument
+// template. If no argument contains {prompt}, the prompt is piped to the
+// command's stdin. Stdout is treated as plain text: every line is streamed
+// as it arrives and the last JSON block in it is the response.
+//
+// A CommandBackend cannot translate RunOptions.Permissions for an unknown
+// CLI; the command is responsible for its own sandboxing.
+type CommandBackend struct {
+	argv  []string
+	model string
+}
+
+// NewCommandBackend creates a CommandBackend for the argument template argv,
+// whose first element is the executable.
+func NewCommandBackend(argv []string, model string) (*CommandBackend, error) {
+	if len(argv) == 0 || argv[0] == "" {
+		return nil, fmt.Errorf("process: command backend: empty command")
+	}
+	return &CommandBackend{argv: argv, model: model}, nil
+}
+
+// RunWithOptions runs the command with the template filled in.
+func (c *CommandBackend) RunWithOptions(ctx context.Context, workDir string, prompt string, opts RunOptions) *RunResult {
+	model := c.model
+	if opts.Model != "" {
+		model = opts.Model
+	}
+	replacer := strings.NewReplacer(
+		PlaceholderPrompt, prompt,
+		PlaceholderModel, model,
+		PlaceholderWorkDir, workDir,
+	)
+	args := make([]string, len(c.argv)-1)
+	viaStdin := true
+	for i, a := range c.argv[1:] {
+		if strings.Contains(a, PlaceholderPrompt) {
+			viaStdin = false
+		}
+		args[i] = replacer.Replace(a)
+	}
+
+	cmd := exec.CommandContext(ctx, c.argv[0], args...)
+	cmd.Dir = workDir
+	if viaStdin {
+		cmd.Stdin = strings.NewReader(prompt)
+	}
+	if len(opts.Env) > 0 {
+		cmd.Env = append(cmd.Environ(), opts.Env...)
+	}
+
+	result := runProcess(cmd, opts.OnStdout, opts.OnStderr)
+	result.Model = model
+	result.Text = result.Stdout
+	result.JSONBlock = extractLastJSON(result.Stdout)
+	return result
+}
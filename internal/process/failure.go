@@ -0,0 +1,86 @@
+package process
+
+import (
+	"errors"
+	"net"
+	"strings"
+)
+
+// Failure classifies why an agent run failed.
+type Failure string
+
+// Failure classes. FailureMalformed is never returned by Classify, which
+// only sees the run itself; callers that parse the response use it when the
+// response is missing or unparseable.
+const (
+	FailureNone      Failure = ""
+	FailureRateLimit Failure = "rate_limit" // the provider is throttling the caller
+	FailureOverload  Failure = "overload"   // the model is temporarily overloaded
+	FailureNetwork   Failure = "network"    // the provider could not be reached
+	FailureMalformed Failure = "malformed"  // the run succeeded but its response is unusable
+	FailureGenuine   Failure = "failure"    // anything else; another attempt would likely fail too
+)
+
+// failureMarkers are substrings, matched case-insensitively against a failed
+// run's error, stderr and error result, that identify its class. They are
+// checked in order.
+var failureMarkers = []struct {
+	class   Failure
+	markers []string
+}{
+	{FailureRateLimit, []string{"rate limit", "rate_limit", "too many requests"}},
+	{FailureOverload, []string{"overloaded", "service unavailable", "temporarily unavailable"}},
+	{FailureNetwork, []string{
+		"connection refused", "connection reset", "no such host", "network is unreachable",
+		"i/o timeout", "timed out", "tls handshake", "socket hang up",
+		"econnrefused", "econnreset", "etimedout", "enotfound", "eai_again",
+	}},
+}
+
+// Classify returns the class of a run's failure, or FailureNone if the run
+// did not fail. A run fails if it returned an error or its final stream
+// event is an error.
+func Classify(result *RunResult) Failure {
+	if result.Err == nil && !resultIsError(result) {
+		return FailureNone
+	}
+	var netErr net.Error
+	if errors.As(result.Err, &netErr) {
+		return FailureNetwork
+	}
+
+	var msg strings.Builder
+	if result.Err != nil {
+		msg.WriteString(result.Err.Error())
+	}
+	msg.WriteString("\n" + result.Stderr)
+	if resultIsError(result) {
+		msg.WriteString("\n" + result.Text)
+	}
+	text := strings.ToLower(msg.String())
+	for _, fm := range failureMarkers {
+		for _, m := range fm.markers {
+			if strings.Contains(text, m) {
+				return fm.class
+			}
+		}
+	}
+	return FailureGenuine
+}
+
+// Overloaded reports whether a run failed because its model was overloaded
+// or rate limited, rather than for a reason another model would hit too.
+func Overloaded(result *RunResult) bool {
+	c := Classify(result)
+	return c == FailureRateLimit || c == FailureOverload
+}
+
+// resultIsError reports whether the run's final stream event is an error.
+func resultIsError(result *RunResult) bool {
+	for i := len(result.Events) - 1; i >= 0; i-- {
+		if result.Events[i].Kind == StreamResult {
+			return result.Events[i].IsError
+		}
+	}
+	return false
+}
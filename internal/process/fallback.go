@@ -0,0 +1,55 @@
+package process
+
+import (
+	"context"
+	"fmt"
+)
+
+// modelFallback runs prompts on a chain of models, moving down the chain
+// while runs fail with Overloaded.
+type modelFallback struct {
+	backend AgentBackend
+	models  []string
+}
+
+// WithModels returns a backend that runs b with models[0] and, when a run is
+// Overloaded, runs the prompt again with each later model in turn. An empty
+// model means b's own, and a RunOptions.Model given to the returned backend
+// replaces models[0]. If models holds only the empty model, b is returned
+// as is.
+func WithModels(b AgentBackend, models []string) AgentBackend {
+	if len(models) == 0 || (len(models) == 1 && models[0] == "") {
+		return b
+	}
+	return &modelFallback{backend: b, models: models}
+}
+
+// RunWithOptions runs prompt down the model chain.
+func (f *modelFallback) RunWithOptions(ctx context.Context, workDir string, prompt string, opts RunOptions) *RunResult {
+	models := f.models
+	if opts.Model != "" {
+		models = append([]string{opts.Model}, models[1:]...)
+	}
+
+	var result *RunResult
+	for i, model := range models {
+		opts.Model = model
+		result = f.backend.RunWithOptions(ctx, workDir, prompt, opts)
+		if i == len(models)-1 || ctx.Err() != nil || !Overloaded(result) {
+			break
+		}
+		if opts.OnStderr != nil {
+			opts.OnStderr(fmt.Sprintf("%s is overloaded or rate limited; retrying with %s",
+				modelName(model), modelName(models[i+1])))
+		}
+	}
+	return result
+}
+
+// modelName names a model in messages; "" is the backend's default.
+func modelName(model string) string {
+	if model == "" {
+		return "the default model"
+	}
+	return model
+}
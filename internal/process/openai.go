@@ -0,0 +1,190 @@
+package process
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBody bounds how much of an HTTP error response is kept.
+const maxErrorBody = 4 << 10
+
+// OpenAIBackend sends prompts to an OpenAI-compatible chat completions
+// endpoint, such as a local llama.cpp, vLLM or Ollama server, and streams
+// the reply. The model answers in text only: it has no tools, cannot read
+// or change the working directory, and RunOptions.Permissions does not
+// apply. It suits roles that plan and review rather than ones that edit
+// code.
+type OpenAIBackend struct {
+	url    string // chat completions endpoint
+	apiKey string // optional bearer token
+	model  string
+	client *http.Client
+}
+
+// NewOpenAIBackend creates an OpenAIBackend. baseURL is the API root, e.g.
+// "http://localhost:11434/v1"; apiKey may be empty for local servers.
+func NewOpenAIBackend(baseURL, apiKey, model string) (*OpenAIBackend, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("process: openai backend: empty base URL")
+	}
+	if model == "" {
+		return nil, fmt.Errorf("process: openai backend: empty model")
+	}
+	return &OpenAIBackend{
+		url:    strings.TrimRight(baseURL, "/") + "/chat/completions",
+		apiKey: apiKey,
+		model:  model,
+		client: &http.Client{},
+	}, nil
+}
+
+// chatRequest is the request body for a streamed chat completion.
+type chatRequest struct {
+	Model         string        `json:"model"`
+	Messages      []chatMessage `json:"messages"`
+	Stream        bool          `json:"stream"`
+	StreamOptions struct {
+		IncludeUsage bool `json:"include_usage"`
+	} `json:"stream_options"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatChunk is one server-sent event of a streamed chat completion.
+type chatChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// RunWithOptions sends prompt as a single user message. workDir is unused.
+func (o *OpenAIBackend) RunWithOptions(ctx context.Context, workDir string, prompt string, opts RunOptions) *RunResult {
+	model := o.model
+	if opts.Model != "" {
+		model = opts.Model
+	}
+	body := chatRequest{
+		Model:    model,
+		Messages: []chatMessage{{Role: "user", Content: prompt}},
+		Stream:   true,
+	}
+	body.StreamOptions.IncludeUsage = true
+	data, err := json.Marshal(body)
+	if err != nil {
+		return &RunResult{Err: fmt.Errorf("process: openai: encode request: %w", err)}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.url, bytes.NewReader(data))
+	if err != nil {
+		return &RunResult{Err: fmt.Errorf("process: openai: %w", err)}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	if o.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return &RunResult{Err: fmt.Errorf("process: openai: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return &RunResult{
+			Stderr: string(msg),
+			Err:    fmt.Errorf("process: openai: %s: %s", resp.Status, truncate(strings.TrimSpace(string(msg)), maxToolLineLen)),
+		}
+	}
+
+	result := &RunResult{Model: model}
+	var text strings.Builder
+	var pending string // text after the last newline, not yet streamed
+	flush := func(all bool) {
+		for {
+			line, rest, ok := strings.Cut(pending, "\n")
+			if !ok {
+				break
+			}
+			if opts.OnStdout != nil {
+				opts.OnStdout(line)
+			}
+			pending = rest
+		}
+		if all && pending != "" {
+			if opts.OnStdout != nil {
+				opts.OnStdout(pending)
+			}
+			pending = ""
+		}
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 1<<20), 10<<20)
+	for scanner.Scan() {
+		payload, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		payload = strings.TrimSpace(payload)
+		if payload == "[DONE]" {
+			break
+		}
+		var chunk chatChunk
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			continue
+		}
+		if chunk.Error != nil {
+			result.Err = fmt.Errorf("process: openai: %s", chunk.Error.Message)
+			break
+		}
+		if chunk.Usage != nil {
+			result.Usage = &Usage{
+				InputTokens:  chunk.Usage.PromptTokens,
+				OutputTokens: chunk.Usage.CompletionTokens,
+			}
+		}
+		for _, c := range chunk.Choices {
+			text.WriteString(c.Delta.Content)
+			pending += c.Delta.Content
+		}
+		flush(false)
+	}
+	flush(true)
+	if err := scanner.Err(); err != nil && result.Err == nil {
+		result.Err = fmt.Errorf("process: openai: read response: %w", err)
+	}
+
+	result.Stdout = text.String()
+	result.Text = result.Stdout
+	result.JSONBlock = extractLastJSON(result.Text)
+	result.Events = []StreamEvent{
+		{Kind: StreamText, Text: result.Text},
+		{Kind: StreamResult, Text: result.Text, IsError: result.Err != nil, Usage: result.Usage},
+	}
+	if opts.OnEvent != nil {
+		for _, ev := range result.Events {
+			opts.OnEvent(ev)
+		}
+	}
+	return result
+}
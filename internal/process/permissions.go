@@ -0,0 +1,88 @@
+package process
+
+// Permissions restricts the tools a single CLI invocation may use. Allowed
+// and Disallowed hold permission rules in the CLI's syntax, e.g. "Read" or
+// "Bash(go test:*)", and are passed as --allowedTools and --disallowedTools.
+// In print mode the CLI cannot prompt, so any tool not allowed is refused;
+// disallowed rules win over allowed ones.
+//
+// A nil *Permissions keeps the CLI's permission checks off entirely
+// (--dangerously-skip-permissions).
+type Permissions struct {
+	Allowed    []string
+	Disallowed []string
+}
+
+// Tool names used in permission rules.
+const (
+	ToolRead         = "Read"
+	ToolGlob         = "Glob"
+	ToolGrep         = "Grep"
+	ToolLS           = "LS"
+	ToolEdit         = "Edit"
+	ToolMultiEdit    = "MultiEdit"
+	ToolWrite        = "Write"
+	ToolNotebookEdit = "NotebookEdit"
+	ToolTodoWrite    = "TodoWrite"
+	ToolBash         = "Bash"
+)
+
+// readTools inspect the repository without changing it.
+var readTools = []string{ToolRead, ToolGlob, ToolGrep, ToolLS}
+
+// editTools change files in the working directory.
+var editTools = []string{ToolEdit, ToolMultiEdit, ToolWrite, ToolNotebookEdit}
+
+// ReadOnly returns permissions that let an agent read the repository but
+// neither change files nor run commands. Commander and Boss runs use it.
+func ReadOnly() *Permissions {
+	disallowed := append([]string{ToolBash}, editTools...)
+	return &Permissions{
+		Allowed:    append([]string(nil), readTools...),
+		Disallowed: disallowed,
+	}
+}
+
+// ReadWrite returns permissions that let an agent read and edit files and
+// run only the shell commands matched by bash, which are Bash rule
+// patterns such as "go test:*". Rules in deny are refused even if bash
+// matches them.
+func ReadWrite(bash, deny []string) *Permissions {
+	p := &Permissions{}
+	p.Allowed = append(p.Allowed, readTools...)
+	p.Allowed = append(p.Allowed, editTools...)
+	p.Allowed = append(p.Allowed, ToolTodoWrite)
+	for _, pattern := range bash {
+		p.Allowed = append(p.Allowed, BashRule(pattern))
+	}
+	for _, pattern := range deny {
+		p.Disallowed = append(p.Disallowed, BashRule(pattern))
+	}
+	return p
+}
+
+// BashRule returns the permission rule for a Bash command pattern. "*"
+// matches every command.
+func BashRule(pattern string) string {
+	if pattern == "*" {
+		return ToolBash
+	}
+	return ToolBash + "(" + pattern + ")"
+}
+
+// args returns the CLI flags for p.
+func (p *Permissions) args() []string {
+	if p == nil {
+		return []string{"--dangerously-skip-permissions"}
+	}
+	var args []string
+	if len(p.Allowed) > 0 {
+		args = append(args, "--allowedTools")
+		args = append(args, p.Allowed...)
+	}
+	if len(p.Disallowed) > 0 {
+		args = append(args, "--disallowedTools")
+		args = append(args, p.Disallowed...)
+	}
+	return args
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package process
+
+import "os/exec"
+
+// setProcessGroup is a no-op on platforms without Unix process groups;
+// context cancellation kills only the CLI process itself.
+func setProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,390 @@
+package process
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Recording is one agent run captured by a Recorder: the prompt, everything
+// the agent streamed, its result, and the files it changed in the working
+// directory.
+type Recording struct {
+	PromptHash string        `json:"prompt_hash"`
+	Prompt     string        `json:"prompt"`
+	Lines      []string      `json:"lines,omitempty"` // OnStdout lines in order
+	Stderr     []string      `json:"stderr,omitempty"`
+	Events     []StreamEvent `json:"events,omitempty"`
+	Text       string        `json:"text"`
+	JSONBlock  string        `json:"json_block,omitempty"`
+	Usage      *Usage        `json:"usage,omitempty"`
+	CostUSD    float64       `json:"cost_usd,omitempty"`
+	Model      string        `json:"model,omitempty"`
+	ExitCode   int           `json:"exit_code,omitempty"`
+	Err        string        `json:"error,omitempty"`
+	Files      []FileChange  `json:"files,omitempty"`
+}
+
+// FileChange is a file an agent created, modified or deleted, relative to
+// its working directory.
+type FileChange struct {
+	Path    string `json:"path"`
+	Content []byte `json:"content,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// PromptHash returns the key recordings are stored and replayed under.
+func PromptHash(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+// recordingFile returns the file for the nth (1-based) run of a prompt.
+func recordingFile(dir, hash string, n int) string {
+	return filepath.Join(dir, fmt.Sprintf("%s-%d.json", hash[:16], n))
+}
+
+// ---------------------------------------------------------------------------
+// Recorder
+// ---------------------------------------------------------------------------
+
+// Recorder wraps an AgentBackend and writes every run to dir as a
+// Recording. File changes are found by comparing the working directory
+// before and after the run, so workers running in parallel in one worktree
+// may each record the others' edits as well; replaying them is harmless.
+type Recorder struct {
+	backend AgentBackend
+	dir     string
+
+	mu   sync.Mutex
+	runs map[string]int // prompt hash → runs recorded
+}
+
+// NewRecorder creates a Recorder that writes recordings of backend's runs
+// to dir.
+func NewRecorder(backend AgentBackend, dir string) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("process: recorder: %w", err)
+	}
+	return &Recorder{backend: backend, dir: dir, runs: make(map[string]int)}, nil
+}
+
+// RunWithOptions runs the wrapped backend and records the run. A recording
+// that cannot be written is reported on OnStderr; the run's result is
+// returned regardless.
+func (r *Recorder) RunWithOptions(ctx context.Context, workDir string, prompt string, opts RunOptions) *RunResult {
+	before, _ := snapshotDir(workDir)
+
+	rec := &Recording{PromptHash: PromptHash(prompt), Prompt: prompt}
+	var mu sync.Mutex
+	inner := opts
+	inner.OnStdout = func(line string) {
+		mu.Lock()
+		rec.Lines = append(rec.Lines, line)
+		mu.Unlock()
+		if opts.OnStdout != nil {
+			opts.OnStdout(line)
+		}
+	}
+	inner.OnStderr = func(line string) {
+		mu.Lock()
+		rec.Stderr = append(rec.Stderr, line)
+		mu.Unlock()
+		if opts.OnStderr != nil {
+			opts.OnStderr(line)
+		}
+	}
+
+	result := r.backend.RunWithOptions(ctx, workDir, prompt, inner)
+
+	rec.Events = result.Events
+	rec.Text = result.Text
+	rec.JSONBlock = result.JSONBlock
+	rec.Usage = result.Usage
+	rec.CostUSD = result.CostUSD
+	rec.Model = result.Model
+	rec.ExitCode = result.ExitCode
+	if result.Err != nil {
+		rec.Err = result.Err.Error()
+	}
+	if after, err := snapshotDir(workDir); err == nil && before != nil {
+		rec.Files = diffSnapshots(workDir, before, after)
+	}
+
+	if err := r.save(rec); err != nil && opts.OnStderr != nil {
+		opts.OnStderr(fmt.Sprintf("warning: %v", err))
+	}
+	return result
+}
+
+// save writes rec as the next run of its prompt.
+func (r *Recorder) save(rec *Recording) error {
+	r.mu.Lock()
+	r.runs[rec.PromptHash]++
+	n := r.runs[rec.PromptHash]
+	r.mu.Unlock()
+
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("process: recorder: encode: %w", err)
+	}
+	if err := os.WriteFile(recordingFile(r.dir, rec.PromptHash, n), data, 0o644); err != nil {
+		return fmt.Errorf("process: recorder: %w", err)
+	}
+	return nil
+}
+
+// fileStamp identifies a version of a file cheaply.
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// snapshotDir stamps every regular file below dir, skipping .git and .bore.
+func snapshotDir(dir string) (map[string]fileStamp, error) {
+	files := make(map[string]fileStamp)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Name() == ".git" || d.Name() == ".bore" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = fileStamp{size: info.Size(), modTime: info.ModTime()}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// diffSnapshots returns the files that differ between two snapshots of dir,
+// with the current contents of those that still exist, sorted by path.
+func diffSnapshots(dir string, before, after map[string]fileStamp) []FileChange {
+	var changes []FileChange
+	for p, st := range after {
+		if old, ok := before[p]; ok && old == st {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(p)))
+		if err != nil {
+			continue
+		}
+		changes = append(changes, FileChange{Path: p, Content: content})
+	}
+	for p := range before {
+		if _, ok := after[p]; !ok {
+			changes = append(changes, FileChange{Path: p, Deleted: true})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// ---------------------------------------------------------------------------
+// Replayer
+// ---------------------------------------------------------------------------
+
+// Replayer is an AgentBackend that serves recordings from a directory
+// written by a Recorder instead of running an agent. Each run looks up the
+// recording for its prompt hash, applies the recorded file changes to the
+// working directory, and streams the recorded output. A prompt run more
+// often than it was recorded gets its last recording again.
+type Replayer struct {
+	dir string
+
+	mu   sync.Mutex
+	runs map[string]int // prompt hash → runs replayed
+}
+
+// NewReplayer creates a Replayer for the recordings in dir.
+func NewReplayer(dir string) (*Replayer, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("process: replayer: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("process: replayer: %s is not a directory", dir)
+	}
+	return &Replayer{dir: dir, runs: make(map[string]int)}, nil
+}
+
+// RunWithOptions replays the recording for prompt. RunOptions.Permissions
+// is ignored; the recorded run already obeyed whatever it was given.
+func (r *Replayer) RunWithOptions(ctx context.Context, workDir string, prompt string, opts RunOptions) *RunResult {
+	rec, err := r.Next(prompt)
+	if err != nil {
+		return &RunResult{Err: err}
+	}
+	if err := ctx.Err(); err != nil {
+		return &RunResult{Err: fmt.Errorf("process: replay: %w", err)}
+	}
+	if err := ApplyFileChanges(workDir, rec.Files); err != nil {
+		return &RunResult{Err: err}
+	}
+
+	for _, ev := range rec.Events {
+		if opts.OnEvent != nil {
+			opts.OnEvent(ev)
+		}
+	}
+	for _, line := range rec.Lines {
+		if opts.OnStdout != nil {
+			opts.OnStdout(line)
+		}
+	}
+	for _, line := range rec.Stderr {
+		if opts.OnStderr != nil {
+			opts.OnStderr(line)
+		}
+	}
+
+	result := &RunResult{
+		Stdout:    rec.Text,
+		Text:      rec.Text,
+		JSONBlock: rec.JSONBlock,
+		Events:    rec.Events,
+		Usage:     rec.Usage,
+		CostUSD:   rec.CostUSD,
+		Model:     rec.Model,
+		ExitCode:  rec.ExitCode,
+	}
+	if rec.Err != "" {
+		result.Err = errors.New(rec.Err)
+	}
+	return result
+}
+
+// Next returns the recording for the next run of prompt.
+func (r *Replayer) Next(prompt string) (*Recording, error) {
+	hash := PromptHash(prompt)
+	r.mu.Lock()
+	r.runs[hash]++
+	n := r.runs[hash]
+	r.mu.Unlock()
+
+	for ; n >= 1; n-- {
+		data, err := os.ReadFile(recordingFile(r.dir, hash, n))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("process: replay: %w", err)
+		}
+		var rec Recording
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, fmt.Errorf("process: replay: decode %s: %w", recordingFile(r.dir, hash, n), err)
+		}
+		return &rec, nil
+	}
+	return nil, fmt.Errorf("process: replay: no recording for prompt %s in %s", hash[:16], r.dir)
+}
+
+// ApplyFileChanges writes or deletes each changed file below dir.
+func ApplyFileChanges(dir string, changes []FileChange) error {
+	for _, c := range changes {
+		if !filepath.IsLocal(filepath.FromSlash(c.Path)) {
+			return fmt.Errorf("process: replay: refusing to write outside the working directory: %s", c.Path)
+		}
+		p := filepath.Join(dir, filepath.FromSlash(c.Path))
+		if c.Deleted {
+			if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("process: replay: %w", err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return fmt.Errorf("process: replay: %w", err)
+		}
+		if err := os.WriteFile(p, c.Content, 0o644); err != nil {
+			return fmt.Errorf("process: replay: %w", err)
+		}
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Fake CLI output
+// ---------------------------------------------------------------------------
+
+// StreamJSON renders rec as the Claude CLI's stream-json output, one line
+// per event, so a fake claude executable can replay it through the Runner.
+// Recordings without events (legacy or non-Claude backends) are rendered
+// as one assistant message and a result.
+func (rec *Recording) StreamJSON() []string {
+	events := rec.Events
+	if len(events) == 0 {
+		events = []StreamEvent{
+			{Kind: StreamText, Text: rec.Text},
+			{Kind: StreamResult, Text: rec.Text, IsError: rec.Err != "", Usage: rec.Usage, CostUSD: rec.CostUSD},
+		}
+	}
+	var lines []string
+	for _, ev := range events {
+		sl := streamLine{SessionID: ev.SessionID}
+		switch ev.Kind {
+		case StreamInit:
+			sl.Type, sl.Subtype, sl.Model = "system", "init", ev.Model
+		case StreamText:
+			sl.Type = "assistant"
+			sl.Message = streamMessage([]streamContent{{Type: "text", Text: ev.Text}}, ev.Usage)
+		case StreamToolUse:
+			sl.Type = "assistant"
+			sl.Message = streamMessage([]streamContent{{Type: "tool_use", ID: ev.ToolUseID, Name: ev.Tool, Input: ev.Input}}, ev.Usage)
+		case StreamToolResult:
+			content, _ := json.Marshal(ev.Text)
+			sl.Type = "user"
+			sl.Message = streamMessage([]streamContent{{Type: "tool_result", ToolUseID: ev.ToolUseID, Content: content, IsError: ev.IsError}}, nil)
+		case StreamResult:
+			sl.Type, sl.Subtype = "result", "success"
+			if ev.IsError {
+				sl.Subtype = "error"
+			}
+			sl.Result, sl.IsError, sl.Usage = ev.Text, ev.IsError, ev.Usage
+			sl.TotalCostUSD, sl.NumTurns = ev.CostUSD, ev.NumTurns
+		default:
+			continue
+		}
+		data, err := json.Marshal(sl)
+		if err != nil {
+			continue
+		}
+		lines = append(lines, string(data))
+	}
+	return lines
+}
+
+// streamMessage builds the message field of a streamLine.
+func streamMessage(content []streamContent, usage *Usage) *struct {
+	Content []streamContent `json:"content"`
+	Usage   *Usage          `json:"usage"`
+} {
+	return &struct {
+		Content []streamContent `json:"content"`
+		Usage   *Usage          `json:"usage"`
+	}{Content: content, Usage: usage}
+}
@@ -5,15 +5,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// killWaitDelay bounds how long Wait waits for output pipes to close after
+// the CLI process has exited or been killed.
+const killWaitDelay = 5 * time.Second
+
 // Runner executes Claude CLI as an external process.
 type Runner struct {
 	cliPath string // path to claude binary (default "claude")
 	model   string // optional model override (pass as flag if non-empty)
+
+	// legacy is set once the CLI rejects --output-format stream-json; later
+	// runs then use plain text output and extractLastJSON.
+	legacy atomic.Bool
 }
 
 // NewRunner creates a Runner. cliPath is the path/name of the claude binary.
@@ -30,35 +41,142 @@ func NewRunner(cliPath string, model string) *Runner {
 
 // RunResult holds the outcome of a CLI invocation.
 type RunResult struct {
-	Stdout    string // full stdout accumulated
+	Stdout    string // full stdout accumulated (raw stream-json lines unless Legacy)
 	Stderr    string // full stderr accumulated
 	ExitCode  int
-	JSONBlock string // extracted last JSON block
+	Text      string        // final response text
+	JSONBlock string        // last JSON block extracted from Text
+	Events    []StreamEvent // typed stream events; empty for legacy output
+	Usage     *Usage        // token usage reported in the final result, if any
+	CostUSD   float64       // total cost reported in the final result, if any
+	Model     string        // model that answered, if known
+	Legacy    bool          // the CLI did not support stream-json output
 	Err       error
 }
 
-// Run executes claude CLI with the given prompt piped via stdin.
+// RunOptions configures a single CLI invocation.
+type RunOptions struct {
+	// Env is optional additional environment variables (key=value strings).
+	Env []string
+	// OnStdout receives human-readable output lines as they arrive: the
+	// rendered stream events, or raw stdout lines for legacy output.
+	OnStdout func(line string)
+	// OnStderr receives each stderr line as it arrives.
+	OnStderr func(line string)
+	// OnEvent receives each typed stream event as it is parsed.
+	OnEvent func(ev StreamEvent)
+	// Permissions restricts the tools the CLI may use; nil skips the CLI's
+	// permission checks.
+	Permissions *Permissions
+	// Model, if non-empty, replaces the backend's own model for this run.
+	Model string
+}
+
+// Run executes claude CLI with the given prompt piped via stdin, with the
+// CLI's permission checks skipped; use RunWithOptions to restrict its tools.
 // It runs in the specified workDir (for Workers this is the worktree directory).
 // env is optional additional environment variables (key=value strings).
 // onStdout and onStderr are called for each line of output as it arrives (for live streaming to TUI/logs).
 // These callbacks may be nil.
 // The function blocks until the process exits.
 func (r *Runner) Run(ctx context.Context, workDir string, prompt string, env []string, onStdout func(line string), onStderr func(line string)) *RunResult {
-	// TODO: consider passing --output-format json if Claude CLI supports it,
-	// to ensure structured output instead of relying on extractLastJSON.
-	args := []string{"-p", "--dangerously-skip-permissions"}
-	if r.model != "" {
-		args = append(args, "--model", r.model)
+	return r.RunWithOptions(ctx, workDir, prompt, RunOptions{Env: env, OnStdout: onStdout, OnStderr: onStderr})
+}
+
+// RunWithOptions is like Run but also exposes typed stream events. It
+// requests --output-format stream-json and falls back to plain text output
+// (and extractLastJSON) if the installed CLI does not support it.
+func (r *Runner) RunWithOptions(ctx context.Context, workDir string, prompt string, opts RunOptions) *RunResult {
+	if !r.legacy.Load() {
+		result := r.run(ctx, workDir, prompt, opts, true)
+		if !streamUnsupported(result) {
+			return result
+		}
+		r.legacy.Store(true)
+	}
+	return r.run(ctx, workDir, prompt, opts, false)
+}
+
+// streamUnsupported reports whether a stream-json run failed because the CLI
+// does not know the flag, rather than for any other reason.
+func streamUnsupported(result *RunResult) bool {
+	if result.Err == nil || len(result.Events) > 0 || result.ExitCode <= 0 {
+		return false
+	}
+	msg := strings.ToLower(result.Stderr)
+	return strings.Contains(msg, "output-format") ||
+		strings.Contains(msg, "unknown option") ||
+		strings.Contains(msg, "unrecognized option")
+}
+
+func (r *Runner) run(ctx context.Context, workDir string, prompt string, opts RunOptions, stream bool) *RunResult {
+	args := append([]string{"-p"}, opts.Permissions.args()...)
+	if stream {
+		// The CLI requires --verbose to emit stream-json in print mode.
+		args = append(args, "--output-format", "stream-json", "--verbose")
+	}
+	model := r.model
+	if opts.Model != "" {
+		model = opts.Model
+	}
+	if model != "" {
+		args = append(args, "--model", model)
 	}
 
 	cmd := exec.CommandContext(ctx, r.cliPath, args...)
 	cmd.Dir = workDir
 	cmd.Stdin = strings.NewReader(prompt)
+	if len(opts.Env) > 0 {
+		cmd.Env = append(cmd.Environ(), opts.Env...)
+	}
+
+	var events []StreamEvent
+	onStdout := func(line string) {
+		if stream {
+			if evs, ok := parseStreamLine(line); ok {
+				for _, ev := range evs {
+					events = append(events, ev)
+					if opts.OnEvent != nil {
+						opts.OnEvent(ev)
+					}
+					if opts.OnStdout != nil {
+						for _, l := range ev.Lines() {
+							opts.OnStdout(l)
+						}
+					}
+				}
+				return
+			}
+		}
+		if opts.OnStdout != nil {
+			opts.OnStdout(line)
+		}
+	}
+
+	result := runProcess(cmd, onStdout, opts.OnStderr)
+	result.Events = events
+	result.Legacy = !stream
+	result.Model = model
 
-	if len(env) > 0 {
-		cmd.Env = append(cmd.Environ(), env...)
+	if stream && len(events) > 0 {
+		applyStreamResult(result)
+	} else {
+		result.Text = result.Stdout
+		result.JSONBlock = extractLastJSON(result.Stdout)
 	}
 
+	return result
+}
+
+// runProcess starts cmd, calls onStdout and onStderr (either may be nil)
+// for each output line as it arrives, and waits for it to exit. The result
+// carries the accumulated output, the exit code and any error.
+func runProcess(cmd *exec.Cmd, onStdout, onStderr func(line string)) *RunResult {
+	setProcessGroup(cmd)
+	// Don't let orphaned grandchildren holding the pipes open block Wait
+	// after the process group has been killed.
+	cmd.WaitDelay = killWaitDelay
+
 	stdoutPipe, err := cmd.StdoutPipe()
 	if err != nil {
 		return &RunResult{Err: fmt.Errorf("process: stdout pipe: %w", err)}
@@ -76,41 +194,8 @@ func (r *Runner) Run(ctx context.Context, workDir string, prompt string, env []s
 	var stdoutBuf, stderrBuf strings.Builder
 	var wg sync.WaitGroup
 	wg.Add(2)
-
-	go func() {
-		defer wg.Done()
-		scanner := bufio.NewScanner(stdoutPipe)
-		scanner.Buffer(make([]byte, 0, 1<<20), 10<<20)
-		for scanner.Scan() {
-			line := scanner.Text()
-			stdoutBuf.WriteString(line)
-			stdoutBuf.WriteByte('\n')
-			if onStdout != nil {
-				onStdout(line)
-			}
-		}
-		if err := scanner.Err(); err != nil {
-			stdoutBuf.WriteString(fmt.Sprintf("[scanner error: %v]\n", err))
-		}
-	}()
-
-	go func() {
-		defer wg.Done()
-		scanner := bufio.NewScanner(stderrPipe)
-		scanner.Buffer(make([]byte, 0, 1<<20), 10<<20)
-		for scanner.Scan() {
-			line := scanner.Text()
-			stderrBuf.WriteString(line)
-			stderrBuf.WriteByte('\n')
-			if onStderr != nil {
-				onStderr(line)
-			}
-		}
-		if err := scanner.Err(); err != nil {
-			stderrBuf.WriteString(fmt.Sprintf("[scanner error: %v]\n", err))
-		}
-	}()
-
+	go scanLines(&wg, stdoutPipe, &stdoutBuf, onStdout)
+	go scanLines(&wg, stderrPipe, &stderrBuf, onStderr)
 	wg.Wait()
 
 	result := &RunResult{
@@ -127,8 +212,63 @@ func (r *Runner) Run(ctx context.Context, workDir string, prompt string, env []s
 			result.ExitCode = -1
 		}
 	}
+	return result
+}
+
+// scanLines copies r into buf line by line, calling onLine for each line.
+func scanLines(wg *sync.WaitGroup, r io.Reader, buf *strings.Builder, onLine func(line string)) {
+	defer wg.Done()
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1<<20), 10<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+		if onLine != nil {
+			onLine(line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		buf.WriteString(fmt.Sprintf("[scanner error: %v]\n", err))
+	}
+}
+
+// applyStreamResult derives Text, JSONBlock, Usage, CostUSD and Model from
+// the parsed stream events. The final result event is authoritative; if it is
+// missing (e.g. the process was killed) the assistant text seen so far is
+// used instead.
+func applyStreamResult(result *RunResult) {
+	var assistant []string
+	var final *StreamEvent
+	for i := range result.Events {
+		ev := &result.Events[i]
+		switch ev.Kind {
+		case StreamInit:
+			if ev.Model != "" {
+				result.Model = ev.Model
+			}
+		case StreamText:
+			assistant = append(assistant, ev.Text)
+		case StreamResult:
+			final = ev
+		}
+	}
 
-	result.JSONBlock = extractLastJSON(result.Stdout)
+	if final != nil {
+		result.Text = final.Text
+		result.Usage = final.Usage
+		result.CostUSD = final.CostUSD
+		if final.IsError && result.Err == nil {
+			result.Err = fmt.Errorf("process: claude reported an error: %s", truncate(final.Text, maxToolLineLen))
+		}
+	} else {
+		result.Text = strings.Join(assistant, "\n")
+	}
 
-	return result
+	result.JSONBlock = extractLastJSON(result.Text)
+	if result.JSONBlock == "" && final != nil {
+		// The JSON may have been emitted in an earlier assistant message
+		// followed by a short closing remark.
+		result.JSONBlock = extractLastJSON(strings.Join(assistant, "\n"))
+	}
 }
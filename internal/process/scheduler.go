@@ -73,7 +73,9 @@ func (s *Scheduler) Release() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if len(s.waiters) > 0 {
+	// Hand the slot straight to the next waiter, unless SetMaxWorkers has
+	// lowered the limit below the workers still running.
+	if len(s.waiters) > 0 && s.active <= s.maxSlots {
 		next := s.waiters[0]
 		s.waiters = s.waiters[1:]
 		next <- struct{}{}
@@ -86,6 +88,25 @@ func (s *Scheduler) Release() {
 	s.active--
 }
 
+// SetMaxWorkers changes the max concurrent workers. Raising it admits
+// waiting requests at once; lowering it lets running workers finish but
+// admits no more until fewer than maxWorkers are running.
+func (s *Scheduler) SetMaxWorkers(maxWorkers int) {
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maxSlots = maxWorkers
+	for s.active < s.maxSlots && len(s.waiters) > 0 {
+		next := s.waiters[0]
+		s.waiters = s.waiters[1:]
+		s.active++
+		next <- struct{}{}
+	}
+}
+
 // Active returns the current number of active workers.
 func (s *Scheduler) Active() int {
 	s.mu.Lock()
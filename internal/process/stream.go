@@ -0,0 +1,199 @@
+package process
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// StreamEventKind identifies the type of a StreamEvent.
+type StreamEventKind string
+
+const (
+	// StreamInit is the CLI's session start notice.
+	StreamInit StreamEventKind = "init"
+	// StreamText is a block of assistant text.
+	StreamText StreamEventKind = "text"
+	// StreamToolUse is a tool invocation requested by the assistant.
+	StreamToolUse StreamEventKind = "tool_use"
+	// StreamToolResult is the output of a tool invocation.
+	StreamToolResult StreamEventKind = "tool_result"
+	// StreamResult is the final result of the run, including usage.
+	StreamResult StreamEventKind = "result"
+)
+
+// Usage is the token accounting reported by the Claude CLI.
+type Usage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+}
+
+// StreamEvent is one typed event parsed from the CLI's stream-json output.
+type StreamEvent struct {
+	Kind      StreamEventKind
+	Text      string          // assistant text, tool result content, or final result text
+	Tool      string          // tool name (tool_use)
+	ToolUseID string          // links tool_use and tool_result events
+	Input     json.RawMessage // tool input (tool_use)
+	IsError   bool            // tool_result or result reported an error
+	Usage     *Usage          // per-message usage (text, tool_use) or run totals (result)
+	CostUSD   float64         // total cost (result)
+	NumTurns  int             // agentic turns taken (result)
+	SessionID string          // CLI session ID (init, result)
+	Model     string          // model in use (init)
+}
+
+// maxToolLineLen bounds the rendering of tool inputs and results in Lines.
+const maxToolLineLen = 160
+
+// Lines renders the event as human-readable output lines for logs and live
+// views. Events with nothing worth showing return nil.
+func (ev StreamEvent) Lines() []string {
+	switch ev.Kind {
+	case StreamInit:
+		if ev.Model != "" {
+			return []string{fmt.Sprintf("session started (model %s)", ev.Model)}
+		}
+		return []string{"session started"}
+	case StreamText:
+		return strings.Split(strings.TrimRight(ev.Text, "\n"), "\n")
+	case StreamToolUse:
+		return []string{fmt.Sprintf("→ %s %s", ev.Tool, truncate(compactJSON(ev.Input), maxToolLineLen))}
+	case StreamToolResult:
+		first, _, _ := strings.Cut(strings.TrimSpace(ev.Text), "\n")
+		if ev.IsError {
+			return []string{"← error: " + truncate(first, maxToolLineLen)}
+		}
+		return []string{fmt.Sprintf("← %s (%d bytes)", truncate(first, maxToolLineLen), len(ev.Text))}
+	default:
+		return nil
+	}
+}
+
+// streamLine is the wire shape of one stream-json output line.
+type streamLine struct {
+	Type      string `json:"type"`
+	Subtype   string `json:"subtype"`
+	SessionID string `json:"session_id"`
+	Model     string `json:"model"`
+	Message   *struct {
+		Content []streamContent `json:"content"`
+		Usage   *Usage          `json:"usage"`
+	} `json:"message"`
+	Result       string  `json:"result"`
+	IsError      bool    `json:"is_error"`
+	Usage        *Usage  `json:"usage"`
+	TotalCostUSD float64 `json:"total_cost_usd"`
+	NumTurns     int     `json:"num_turns"`
+}
+
+// streamContent is one content block of an assistant or user message.
+type streamContent struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Input     json.RawMessage `json:"input"`
+	ToolUseID string          `json:"tool_use_id"`
+	Content   json.RawMessage `json:"content"`
+	IsError   bool            `json:"is_error"`
+}
+
+// parseStreamLine decodes one line of stream-json output. ok is false if the
+// line is not a stream-json object, in which case the caller should treat it
+// as plain text.
+func parseStreamLine(line string) (events []StreamEvent, ok bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "{") {
+		return nil, false
+	}
+	var sl streamLine
+	if err := json.Unmarshal([]byte(line), &sl); err != nil || sl.Type == "" {
+		return nil, false
+	}
+
+	switch sl.Type {
+	case "system":
+		if sl.Subtype == "init" {
+			events = append(events, StreamEvent{Kind: StreamInit, SessionID: sl.SessionID, Model: sl.Model})
+		}
+	case "assistant":
+		if sl.Message == nil {
+			break
+		}
+		for _, c := range sl.Message.Content {
+			switch c.Type {
+			case "text":
+				events = append(events, StreamEvent{Kind: StreamText, Text: c.Text, Usage: sl.Message.Usage})
+			case "tool_use":
+				events = append(events, StreamEvent{Kind: StreamToolUse, Tool: c.Name, ToolUseID: c.ID, Input: c.Input, Usage: sl.Message.Usage})
+			}
+		}
+	case "user":
+		if sl.Message == nil {
+			break
+		}
+		for _, c := range sl.Message.Content {
+			if c.Type == "tool_result" {
+				events = append(events, StreamEvent{Kind: StreamToolResult, ToolUseID: c.ToolUseID, Text: toolResultText(c.Content), IsError: c.IsError})
+			}
+		}
+	case "result":
+		events = append(events, StreamEvent{
+			Kind:      StreamResult,
+			Text:      sl.Result,
+			IsError:   sl.IsError,
+			Usage:     sl.Usage,
+			CostUSD:   sl.TotalCostUSD,
+			NumTurns:  sl.NumTurns,
+			SessionID: sl.SessionID,
+		})
+	}
+	return events, true
+}
+
+// toolResultText flattens a tool_result content field, which the CLI emits
+// either as a plain string or as an array of text blocks.
+func toolResultText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var blocks []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(raw, &blocks); err == nil {
+		var parts []string
+		for _, b := range blocks {
+			if b.Text != "" {
+				parts = append(parts, b.Text)
+			}
+		}
+		return strings.Join(parts, "\n")
+	}
+	return string(raw)
+}
+
+// compactJSON returns raw with insignificant whitespace removed.
+func compactJSON(raw json.RawMessage) string {
+	var b bytes.Buffer
+	if err := json.Compact(&b, raw); err != nil {
+		return string(raw)
+	}
+	return b.String()
+}
+
+// truncate shortens s to at most n bytes, marking the cut with an ellipsis.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "…"
+}
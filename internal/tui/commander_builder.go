@@ -9,6 +9,8 @@ import (
 
 	"bore-tui/internal/agents"
 	"bore-tui/internal/app"
+	"bore-tui/internal/config"
+	"bore-tui/internal/process"
 	"bore-tui/internal/theme"
 
 	"github.com/charmbracelet/bubbles/textarea"
@@ -385,20 +387,19 @@ func (c *CommanderBuilderScreen) startScan() tea.Cmd {
 		// Run the Claude CLI. Because Bubble Tea commands must return a single
 		// tea.Msg, we accumulate all output and return it at once. The spinner
 		// gives live feedback while the process runs.
-		result := a.Runner().Run(
+		result := a.Backend(config.RoleCommander).RunWithOptions(
 			context.Background(),
 			repoPath,
 			prompt,
-			nil,
-			nil, // onStdout — no per-line streaming needed; spinner provides feedback
-			nil, // onStderr
+			// No per-line streaming needed; the spinner provides feedback.
+			process.RunOptions{Permissions: process.ReadOnly()},
 		)
 
 		if result.Err != nil {
 			return ErrorMsg{Err: fmt.Errorf("tui: commander brain: scan: %w", result.Err)}
 		}
 
-		text := strings.TrimSpace(result.Stdout)
+		text := strings.TrimSpace(result.Text)
 		return brainScanDoneMsg{text: text}
 	}
 }
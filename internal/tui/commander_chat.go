@@ -8,6 +8,8 @@ import (
 
 	"bore-tui/internal/agents"
 	"bore-tui/internal/app"
+	"bore-tui/internal/config"
+	"bore-tui/internal/process"
 	"bore-tui/internal/theme"
 
 	"github.com/charmbracelet/bubbles/textarea"
@@ -198,20 +200,18 @@ func (c *CommanderChatScreen) sendMessage(text string) tea.Cmd {
 			workDir = repo.Path
 		}
 
-		result := a.Runner().Run(
+		result := a.Backend(config.RoleCommander).RunWithOptions(
 			context.Background(),
 			workDir,
 			fullPrompt,
-			nil,
-			nil,
-			nil,
+			process.RunOptions{Permissions: process.ReadOnly()},
 		)
 
 		if result.Err != nil {
 			return chatErrMsg{err: fmt.Errorf("commander chat: %w", result.Err)}
 		}
 
-		response := strings.TrimSpace(result.Stdout)
+		response := strings.TrimSpace(result.Text)
 		if response == "" {
 			response = "(no response)"
 		}
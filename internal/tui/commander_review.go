@@ -8,7 +8,7 @@ import (
 	"bore-tui/internal/agents"
 	"bore-tui/internal/app"
 	"bore-tui/internal/db"
-	"bore-tui/internal/git"
+	"bore-tui/internal/engine"
 	"bore-tui/internal/theme"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -21,6 +21,7 @@ import (
 // Steps: 0=clarifications, 1=options, 2=branch_select, 3=brief, 4=approve.
 type CommanderReviewScreen struct {
 	app    *app.App
+	engine *engine.Engine
 	styles theme.Styles
 
 	task *db.Task
@@ -53,10 +54,11 @@ type CommanderReviewScreen struct {
 }
 
 // NewCommanderReviewScreen creates a new CommanderReviewScreen.
-func NewCommanderReviewScreen(a *app.App, styles theme.Styles) CommanderReviewScreen {
+func NewCommanderReviewScreen(a *app.App, eng *engine.Engine, styles theme.Styles) CommanderReviewScreen {
 	vp := viewport.New(0, 0)
 	return CommanderReviewScreen{
 		app:      a,
+		engine:   eng,
 		styles:   styles,
 		answers:  make(map[string]string),
 		viewport: vp,
@@ -421,88 +423,27 @@ type execStartData struct {
 // ---------------------------------------------------------------------------
 
 func (s *CommanderReviewScreen) fetchClarifications() tea.Cmd {
-	a := s.app
+	eng := s.engine
 	task := s.task
 	return func() tea.Msg {
-		ctx := context.Background()
-
-		cluster := a.Cluster()
-		if cluster == nil {
-			return ErrorMsg{Err: fmt.Errorf("no cluster open")}
-		}
-
-		// Build commander context from DB.
-		cmdCtx, err := buildCommanderContext(ctx, a)
+		resp, err := eng.Clarify(context.Background(), task)
 		if err != nil {
-			return ErrorMsg{Err: fmt.Errorf("build commander context: %w", err)}
+			return ErrorMsg{Err: err}
 		}
-
-		systemPrompt := agents.BuildCommanderSystemPrompt(cmdCtx)
-		userPrompt := agents.BuildClarificationPrompt(task.Prompt)
-		fullPrompt := systemPrompt + "\n\n" + userPrompt
-
-		result := a.Runner().Run(ctx, cluster.RepoPath, fullPrompt, nil, nil, nil)
-		if result.Err != nil {
-			return ErrorMsg{Err: fmt.Errorf("commander clarifications: %w", result.Err)}
-		}
-		if result.JSONBlock == "" {
-			return ErrorMsg{Err: fmt.Errorf("no JSON response from commander (clarifications)")}
-		}
-
-		parsed, err := agents.ParseResponse(result.JSONBlock)
-		if err != nil {
-			return ErrorMsg{Err: fmt.Errorf("parse clarifications: %w", err)}
-		}
-
-		resp, ok := parsed.(agents.ClarificationsResponse)
-		if !ok {
-			return ErrorMsg{Err: fmt.Errorf("unexpected response type for clarifications: %T", parsed)}
-		}
-
-		return ClarificationsReceivedMsg{Response: resp}
+		return ClarificationsReceivedMsg{Response: *resp}
 	}
 }
 
 func (s *CommanderReviewScreen) fetchOptions() tea.Cmd {
-	a := s.app
+	eng := s.engine
 	task := s.task
 	answers := s.answers
 	return func() tea.Msg {
-		ctx := context.Background()
-
-		cluster := a.Cluster()
-		if cluster == nil {
-			return ErrorMsg{Err: fmt.Errorf("no cluster open")}
-		}
-
-		cmdCtx, err := buildCommanderContext(ctx, a)
+		resp, err := eng.ProposeOptions(context.Background(), task, answers)
 		if err != nil {
-			return ErrorMsg{Err: fmt.Errorf("build commander context: %w", err)}
-		}
-
-		systemPrompt := agents.BuildCommanderSystemPrompt(cmdCtx)
-		userPrompt := agents.BuildOptionsPrompt(task.Prompt, answers)
-		fullPrompt := systemPrompt + "\n\n" + userPrompt
-
-		result := a.Runner().Run(ctx, cluster.RepoPath, fullPrompt, nil, nil, nil)
-		if result.Err != nil {
-			return ErrorMsg{Err: fmt.Errorf("commander options: %w", result.Err)}
-		}
-		if result.JSONBlock == "" {
-			return ErrorMsg{Err: fmt.Errorf("no JSON response from commander (options)")}
-		}
-
-		parsed, err := agents.ParseResponse(result.JSONBlock)
-		if err != nil {
-			return ErrorMsg{Err: fmt.Errorf("parse options: %w", err)}
-		}
-
-		resp, ok := parsed.(agents.OptionsResponse)
-		if !ok {
-			return ErrorMsg{Err: fmt.Errorf("unexpected response type for options: %T", parsed)}
+			return ErrorMsg{Err: err}
 		}
-
-		return OptionsReceivedMsg{Response: resp}
+		return OptionsReceivedMsg{Response: *resp}
 	}
 }
 
@@ -519,83 +460,28 @@ func (s *CommanderReviewScreen) fetchBranches() tea.Cmd {
 }
 
 func (s *CommanderReviewScreen) fetchBrief(selectedOptionID, baseBranch string) tea.Cmd {
-	a := s.app
+	eng := s.engine
 	task := s.task
 	return func() tea.Msg {
-		ctx := context.Background()
-
-		cluster := a.Cluster()
-		if cluster == nil {
-			return ErrorMsg{Err: fmt.Errorf("no cluster open")}
-		}
-
-		cmdCtx, err := buildCommanderContext(ctx, a)
-		if err != nil {
-			return ErrorMsg{Err: fmt.Errorf("build commander context: %w", err)}
-		}
-
-		systemPrompt := agents.BuildCommanderSystemPrompt(cmdCtx)
-		userPrompt := agents.BuildExecutionBriefPrompt(task.Prompt, selectedOptionID, baseBranch)
-		fullPrompt := systemPrompt + "\n\n" + userPrompt
-
-		result := a.Runner().Run(ctx, cluster.RepoPath, fullPrompt, nil, nil, nil)
-		if result.Err != nil {
-			return ErrorMsg{Err: fmt.Errorf("commander brief: %w", result.Err)}
-		}
-		if result.JSONBlock == "" {
-			return ErrorMsg{Err: fmt.Errorf("no JSON response from commander (brief)")}
-		}
-
-		parsed, err := agents.ParseResponse(result.JSONBlock)
+		resp, err := eng.DraftBrief(context.Background(), task, selectedOptionID, baseBranch)
 		if err != nil {
-			return ErrorMsg{Err: fmt.Errorf("parse brief: %w", err)}
+			return ErrorMsg{Err: err}
 		}
-
-		resp, ok := parsed.(agents.ExecutionBrief)
-		if !ok {
-			return ErrorMsg{Err: fmt.Errorf("unexpected response type for brief: %T", parsed)}
-		}
-
-		return BriefReceivedMsg{Response: resp}
+		return BriefReceivedMsg{Response: *resp}
 	}
 }
 
 func (s *CommanderReviewScreen) startExecution() tea.Cmd {
-	a := s.app
+	eng := s.engine
 	task := s.task
 	brief := s.brief
 	return func() tea.Msg {
-		ctx := context.Background()
-
-		// Determine the base branch and generate the execution branch name.
-		baseBranch := brief.BaseBranch
-		if baseBranch == "" {
-			baseBranch = "main"
-		}
-
-		// Look up thread name for branch naming.
-		thread, err := a.DB().GetThread(ctx, task.ThreadID)
+		// Record the approval and create the execution record and its
+		// worktree; the execution view hands it to the engine along with
+		// the brief.
+		exec, err := eng.ApproveBrief(context.Background(), task, brief)
 		if err != nil {
-			return ErrorMsg{Err: fmt.Errorf("get thread for branch name: %w", err)}
-		}
-
-		execBranch := git.MakeExecBranch(thread.Name, task.ID, task.Title)
-		worktreePath := fmt.Sprintf("%s/worktrees/%s", a.BoreDir(), git.Slugify(task.Title))
-
-		// Create execution record in DB.
-		exec, err := a.DB().CreateExecution(ctx, task.ID, task.ClusterID, nil, baseBranch, execBranch, worktreePath)
-		if err != nil {
-			return ErrorMsg{Err: fmt.Errorf("create execution: %w", err)}
-		}
-
-		// Create git worktree with the new branch.
-		if err := a.Repo().CreateWorktreeNewBranch(ctx, worktreePath, execBranch, baseBranch); err != nil {
-			return ErrorMsg{Err: fmt.Errorf("create worktree: %w", err)}
-		}
-
-		// Update task status to running.
-		if err := a.DB().UpdateTaskStatus(ctx, task.ID, db.StatusRunning); err != nil {
-			return ErrorMsg{Err: fmt.Errorf("update task status: %w", err)}
+			return ErrorMsg{Err: err}
 		}
 
 		return NavigateMsg{
@@ -619,58 +505,7 @@ func buildCommanderContext(ctx context.Context, a *app.App) (agents.CommanderCon
 	if cluster == nil {
 		return agents.CommanderContext{}, fmt.Errorf("no cluster open")
 	}
-	clusterID := cluster.ID
-
-	brain, err := a.DB().GetAllMemory(ctx, clusterID)
-	if err != nil {
-		return agents.CommanderContext{}, fmt.Errorf("get memory: %w", err)
-	}
-
-	crews, err := a.DB().ListCrews(ctx, clusterID)
-	if err != nil {
-		return agents.CommanderContext{}, fmt.Errorf("list crews: %w", err)
-	}
-
-	threads, err := a.DB().ListThreads(ctx, clusterID)
-	if err != nil {
-		return agents.CommanderContext{}, fmt.Errorf("list threads: %w", err)
-	}
-
-	lessons, err := a.DB().ListAllLessons(ctx, clusterID)
-	if err != nil {
-		return agents.CommanderContext{}, fmt.Errorf("list lessons: %w", err)
-	}
-
-	// Task history: all tasks with their boss summarizer outcomes.
-	taskHistory, err := a.DB().ListTaskHistories(ctx, clusterID)
-	if err != nil {
-		return agents.CommanderContext{}, fmt.Errorf("list task histories: %w", err)
-	}
-
-	// Gather recent agent runs from the most recent executions in this cluster.
-	var pastRuns []db.AgentRun
-	executions, err := a.DB().ListExecutions(ctx, clusterID)
-	if err == nil {
-		limit := 5
-		if len(executions) < limit {
-			limit = len(executions)
-		}
-		for _, ex := range executions[:limit] {
-			runs, err := a.DB().GetAgentRuns(ctx, ex.ID)
-			if err == nil {
-				pastRuns = append(pastRuns, runs...)
-			}
-		}
-	}
-
-	return agents.CommanderContext{
-		Brain:       brain,
-		Crews:       crews,
-		Threads:     threads,
-		TaskHistory: taskHistory,
-		PastRuns:    pastRuns,
-		Lessons:     lessons,
-	}, nil
+	return agents.LoadCommanderContext(ctx, a.DB(), cluster.ID)
 }
 
 // ---------------------------------------------------------------------------
@@ -2,7 +2,6 @@ package tui
 
 import (
 	"fmt"
-	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -21,7 +20,7 @@ type configField struct {
 	label string
 	key   string
 	value string
-	kind  string // "string", "int", "bool"
+	kind  string // "string", "int", "float", "bool"
 }
 
 // ConfigEditorScreen displays and edits the cluster configuration.
@@ -64,11 +63,26 @@ func (s *ConfigEditorScreen) loadFields() {
 		{label: "UI Theme", key: "ui.theme", value: cfg.UI.Theme, kind: "string"},
 		{label: "Claude CLI Path", key: "agents.claude_cli_path", value: cfg.Agents.ClaudeCLIPath, kind: "string"},
 		{label: "Default Model", key: "agents.default_model", value: cfg.Agents.DefaultModel, kind: "string"},
+		{label: "Commander Model", key: "agents.models.commander", value: cfg.Agents.Models[config.RoleCommander], kind: "string"},
+		{label: "Boss Model", key: "agents.models.boss", value: cfg.Agents.Models[config.RoleBoss], kind: "string"},
+		{label: "Worker Model", key: "agents.models.worker", value: cfg.Agents.Models[config.RoleWorker], kind: "string"},
+		{label: "Fallback Models", key: "agents.fallback_models", value: strings.Join(cfg.Agents.FallbackModels, ", "), kind: "string"},
 		{label: "Commander Context Limit", key: "agents.commander_context_limit", value: strconv.Itoa(cfg.Agents.CommanderContextLimit), kind: "int"},
 		{label: "Max Total Workers", key: "agents.max_total_workers", value: strconv.Itoa(cfg.Agents.MaxTotalWorkers), kind: "int"},
 		{label: "Max Workers (Basic)", key: "agents.max_workers_basic", value: strconv.Itoa(cfg.Agents.MaxWorkersBasic), kind: "int"},
 		{label: "Max Workers (Medium)", key: "agents.max_workers_medium", value: strconv.Itoa(cfg.Agents.MaxWorkersMedium), kind: "int"},
 		{label: "Max Workers (Complex)", key: "agents.max_workers_complex", value: strconv.Itoa(cfg.Agents.MaxWorkersComplex), kind: "int"},
+		{label: "Worker Budget", key: "agents.worker_budget", value: strconv.Itoa(cfg.Agents.WorkerBudget), kind: "int"},
+		{label: "Max Boss Rounds", key: "agents.max_boss_rounds", value: strconv.Itoa(cfg.Agents.MaxBossRounds), kind: "int"},
+		{label: "Response Repair Attempts", key: "agents.repair_attempts", value: strconv.Itoa(cfg.Agents.RepairAttempts), kind: "int"},
+		{label: "Ownership Policy", key: "agents.ownership_policy", value: cfg.Agents.OwnershipPolicy, kind: "string"},
+		{label: "Budget per Execution ($)", key: "agents.budget.max_execution_usd", value: formatFloat(cfg.Agents.Budget.MaxExecutionUSD), kind: "float"},
+		{label: "Budget per Execution (tokens)", key: "agents.budget.max_execution_tokens", value: strconv.FormatInt(cfg.Agents.Budget.MaxExecutionTokens, 10), kind: "int"},
+		{label: "Budget per Task ($)", key: "agents.budget.max_task_usd", value: formatFloat(cfg.Agents.Budget.MaxTaskUSD), kind: "float"},
+		{label: "Budget per Task (tokens)", key: "agents.budget.max_task_tokens", value: strconv.FormatInt(cfg.Agents.Budget.MaxTaskTokens, 10), kind: "int"},
+		{label: "Budget per Day ($, from local midnight)", key: "agents.budget.max_daily_usd", value: formatFloat(cfg.Agents.Budget.MaxDailyUSD), kind: "float"},
+		{label: "Budget per Day (tokens, from local midnight)", key: "agents.budget.max_daily_tokens", value: strconv.FormatInt(cfg.Agents.Budget.MaxDailyTokens, 10), kind: "int"},
+		{label: "On Budget Exceeded", key: "agents.budget.on_exceeded", value: cfg.Agents.Budget.OnExceeded, kind: "string"},
 		{label: "Worktree Strategy", key: "git.worktree_strategy", value: cfg.Git.WorktreeStrategy, kind: "string"},
 		{label: "Review Required", key: "git.review_required", value: strconv.FormatBool(cfg.Git.ReviewRequired), kind: "bool"},
 		{label: "Auto Commit", key: "git.auto_commit", value: strconv.FormatBool(cfg.Git.AutoCommit), kind: "bool"},
@@ -206,6 +220,12 @@ func (s *ConfigEditorScreen) commitEdit() tea.Cmd {
 			return nil
 		}
 	}
+	if f.kind == "float" {
+		if _, err := strconv.ParseFloat(newVal, 64); err != nil {
+			s.err = fmt.Errorf("%s must be a number", f.label)
+			return nil
+		}
+	}
 
 	f.value = newVal
 	s.editing = false
@@ -215,7 +235,8 @@ func (s *ConfigEditorScreen) commitEdit() tea.Cmd {
 	return nil
 }
 
-// save writes the edited fields back to a Config and persists it.
+// save writes the edited fields back to a Config, persists it and applies
+// it to the open cluster.
 func (s *ConfigEditorScreen) save() tea.Cmd {
 	cfg := s.buildConfig()
 
@@ -224,28 +245,36 @@ func (s *ConfigEditorScreen) save() tea.Cmd {
 		return nil
 	}
 
-	boreDir := s.app.BoreDir()
-	if boreDir == "" {
+	if s.app.BoreDir() == "" {
 		s.err = fmt.Errorf("no cluster open; cannot save config")
 		return nil
 	}
 
-	cfgPath := filepath.Join(boreDir, "config.json")
-	if err := config.Save(cfg, cfgPath); err != nil {
-		s.err = fmt.Errorf("save config: %w", err)
+	if err := s.app.SaveConfig(cfg); err != nil {
+		s.err = err
 		return nil
 	}
 
 	s.saved = true
 	s.err = nil
 	return func() tea.Msg {
-		return StatusMsg("Configuration saved")
+		return StatusMsg("Configuration saved; logging settings apply when the cluster is reopened")
 	}
 }
 
-// buildConfig assembles a Config from the current field values.
+// buildConfig assembles a Config from the current field values. Settings
+// the editor does not show, such as backends and budget warning thresholds,
+// are carried over from the loaded config.
 func (s *ConfigEditorScreen) buildConfig() *config.Config {
 	cfg := config.DefaultConfig()
+	if loaded := s.app.Config(); loaded != nil {
+		cfg = *loaded
+	}
+	models := make(map[string]string)
+	for role, m := range cfg.Agents.Models {
+		models[role] = m
+	}
+	cfg.Agents.Models = models
 
 	for _, f := range s.fields {
 		switch f.key {
@@ -255,6 +284,20 @@ func (s *ConfigEditorScreen) buildConfig() *config.Config {
 			cfg.Agents.ClaudeCLIPath = f.value
 		case "agents.default_model":
 			cfg.Agents.DefaultModel = f.value
+		case "agents.models.commander", "agents.models.boss", "agents.models.worker":
+			role := strings.TrimPrefix(f.key, "agents.models.")
+			if f.value == "" {
+				delete(models, role)
+			} else {
+				models[role] = f.value
+			}
+		case "agents.fallback_models":
+			cfg.Agents.FallbackModels = nil
+			for _, m := range strings.Split(f.value, ",") {
+				if m = strings.TrimSpace(m); m != "" {
+					cfg.Agents.FallbackModels = append(cfg.Agents.FallbackModels, m)
+				}
+			}
 		case "agents.commander_context_limit":
 			cfg.Agents.CommanderContextLimit, _ = strconv.Atoi(f.value)
 		case "agents.max_total_workers":
@@ -265,6 +308,28 @@ func (s *ConfigEditorScreen) buildConfig() *config.Config {
 			cfg.Agents.MaxWorkersMedium, _ = strconv.Atoi(f.value)
 		case "agents.max_workers_complex":
 			cfg.Agents.MaxWorkersComplex, _ = strconv.Atoi(f.value)
+		case "agents.worker_budget":
+			cfg.Agents.WorkerBudget, _ = strconv.Atoi(f.value)
+		case "agents.max_boss_rounds":
+			cfg.Agents.MaxBossRounds, _ = strconv.Atoi(f.value)
+		case "agents.repair_attempts":
+			cfg.Agents.RepairAttempts, _ = strconv.Atoi(f.value)
+		case "agents.ownership_policy":
+			cfg.Agents.OwnershipPolicy = f.value
+		case "agents.budget.max_execution_usd":
+			cfg.Agents.Budget.MaxExecutionUSD, _ = strconv.ParseFloat(f.value, 64)
+		case "agents.budget.max_execution_tokens":
+			cfg.Agents.Budget.MaxExecutionTokens, _ = strconv.ParseInt(f.value, 10, 64)
+		case "agents.budget.max_task_usd":
+			cfg.Agents.Budget.MaxTaskUSD, _ = strconv.ParseFloat(f.value, 64)
+		case "agents.budget.max_task_tokens":
+			cfg.Agents.Budget.MaxTaskTokens, _ = strconv.ParseInt(f.value, 10, 64)
+		case "agents.budget.max_daily_usd":
+			cfg.Agents.Budget.MaxDailyUSD, _ = strconv.ParseFloat(f.value, 64)
+		case "agents.budget.max_daily_tokens":
+			cfg.Agents.Budget.MaxDailyTokens, _ = strconv.ParseInt(f.value, 10, 64)
+		case "agents.budget.on_exceeded":
+			cfg.Agents.Budget.OnExceeded = f.value
 		case "git.worktree_strategy":
 			cfg.Git.WorktreeStrategy = f.value
 		case "git.review_required":
@@ -411,3 +476,8 @@ func (s *ConfigEditorScreen) wrapContent(sections []string, width, height int) s
 		PaddingLeft(4).
 		Render(content)
 }
+
+// formatFloat renders a float field value without trailing zeros.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -49,7 +49,8 @@ type CrewManagerScreen struct {
 	constraintsInput textinput.Model
 	commandsInput    textinput.Model
 	pathsInput       textinput.Model
-	formFocus        int // 0..4
+	modelInput       textinput.Model
+	formFocus        int // 0..5
 
 	editingCrew *db.Crew // non-nil when editing an existing crew
 	deleteName  string
@@ -77,6 +78,7 @@ func NewCrewManagerScreen(a *app.App, s theme.Styles) CrewManagerScreen {
 		constraintsInput: makeInput("Constraints (comma-separated)", 512),
 		commandsInput:    makeInput("Allowed commands (comma-separated)", 512),
 		pathsInput:       makeInput("Ownership paths (comma-separated)", 512),
+		modelInput:       makeInput("Worker model (empty for the configured one)", 128),
 	}
 }
 
@@ -170,6 +172,7 @@ func (c *CrewManagerScreen) Update(msg tea.Msg) tea.Cmd {
 				c.constraintsInput.SetValue(crew.Constraints)
 				c.commandsInput.SetValue(crew.AllowedCommands)
 				c.pathsInput.SetValue(crew.OwnershipPaths)
+				c.modelInput.SetValue(crew.Model)
 				c.formFocus = 0
 				c.focusField(0)
 				c.statusMsg = ""
@@ -193,11 +196,11 @@ func (c *CrewManagerScreen) Update(msg tea.Msg) tea.Cmd {
 func (c *CrewManagerScreen) updateForm(msg tea.KeyMsg) tea.Cmd {
 	switch msg.String() {
 	case "tab":
-		c.formFocus = (c.formFocus + 1) % 5
+		c.formFocus = (c.formFocus + 1) % 6
 		c.focusField(c.formFocus)
 		return nil
 	case "shift+tab":
-		c.formFocus = (c.formFocus + 4) % 5
+		c.formFocus = (c.formFocus + 5) % 6
 		c.focusField(c.formFocus)
 		return nil
 	case "esc":
@@ -221,6 +224,8 @@ func (c *CrewManagerScreen) updateForm(msg tea.KeyMsg) tea.Cmd {
 		c.commandsInput, cmd = c.commandsInput.Update(msg)
 	case 4:
 		c.pathsInput, cmd = c.pathsInput.Update(msg)
+	case 5:
+		c.modelInput, cmd = c.modelInput.Update(msg)
 	}
 	return cmd
 }
@@ -400,6 +405,7 @@ func (c *CrewManagerScreen) renderForm() string {
 		{"Constraints:", c.constraintsInput},
 		{"Allowed Commands:", c.commandsInput},
 		{"Ownership Paths:", c.pathsInput},
+		{"Worker Model:", c.modelInput},
 	}
 
 	labelStyle := lipgloss.NewStyle().
@@ -441,6 +447,7 @@ func (c *CrewManagerScreen) clearForm() {
 	c.constraintsInput.SetValue("")
 	c.commandsInput.SetValue("")
 	c.pathsInput.SetValue("")
+	c.modelInput.SetValue("")
 }
 
 func (c *CrewManagerScreen) focusField(idx int) {
@@ -449,6 +456,7 @@ func (c *CrewManagerScreen) focusField(idx int) {
 	c.constraintsInput.Blur()
 	c.commandsInput.Blur()
 	c.pathsInput.Blur()
+	c.modelInput.Blur()
 
 	switch idx {
 	case 0:
@@ -461,6 +469,8 @@ func (c *CrewManagerScreen) focusField(idx int) {
 		c.commandsInput.Focus()
 	case 4:
 		c.pathsInput.Focus()
+	case 5:
+		c.modelInput.Focus()
 	}
 }
 
@@ -489,6 +499,7 @@ func (c *CrewManagerScreen) saveCrew() tea.Cmd {
 	constraints := c.constraintsInput.Value()
 	commands := c.commandsInput.Value()
 	paths := c.pathsInput.Value()
+	model := c.modelInput.Value()
 	editing := c.editingCrew
 	a := c.app
 
@@ -512,11 +523,12 @@ func (c *CrewManagerScreen) saveCrew() tea.Cmd {
 			crew.Constraints = constraints
 			crew.AllowedCommands = commands
 			crew.OwnershipPaths = paths
+			crew.Model = model
 			if err := a.DB().UpdateCrew(ctx, &crew); err != nil {
 				return ErrorMsg{Err: err}
 			}
 		} else {
-			_, err := a.DB().CreateCrew(ctx, cluster.ID, name, objective, constraints, commands, paths)
+			_, err := a.DB().CreateCrew(ctx, cluster.ID, name, objective, constraints, commands, paths, model)
 			if err != nil {
 				return ErrorMsg{Err: err}
 			}
@@ -3,7 +3,9 @@ package tui
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"bore-tui/internal/app"
 	"bore-tui/internal/db"
@@ -36,6 +38,8 @@ type DashboardScreen struct {
 	// Center pane — task list
 	tasks        []db.Task
 	executions   []db.Execution
+	usageByTask  map[int64]db.UsageRollup
+	usageToday   db.Usage
 	centerCursor int
 	filterThread int64 // 0 = show all
 
@@ -67,6 +71,7 @@ func (d *DashboardScreen) Init() tea.Cmd {
 		d.loadThreads(),
 		d.loadTasks(),
 		d.loadExecutions(),
+		d.loadUsage(),
 	)
 }
 
@@ -99,6 +104,11 @@ func (d *DashboardScreen) Update(msg tea.Msg) tea.Cmd {
 	case ExecutionsLoadedMsg:
 		d.executions = msg.Executions
 
+	case UsageLoadedMsg:
+		d.usageByTask = msg.ByTask
+		d.usageToday = msg.Today
+		d.updateDetailText()
+
 	case tea.MouseMsg:
 		return d.handleMouse(msg)
 
@@ -166,6 +176,7 @@ func (d *DashboardScreen) Update(msg tea.Msg) tea.Cmd {
 				d.loadThreads(),
 				d.loadTasks(),
 				d.loadExecutions(),
+				d.loadUsage(),
 			)
 		}
 	}
@@ -270,6 +281,38 @@ func (d *DashboardScreen) loadExecutions() tea.Cmd {
 	}
 }
 
+func (d *DashboardScreen) loadUsage() tea.Cmd {
+	a := d.app
+	return func() tea.Msg {
+		cluster := a.Cluster()
+		if cluster == nil {
+			return UsageLoadedMsg{}
+		}
+		ctx := context.Background()
+		byTask, err := a.DB().UsageBy(ctx, cluster.ID, db.UsageByTask)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+		byDay, err := a.DB().UsageBy(ctx, cluster.ID, db.UsageByDay)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		msg := UsageLoadedMsg{ByTask: make(map[int64]db.UsageRollup, len(byTask))}
+		for _, u := range byTask {
+			id, err := strconv.ParseInt(u.Key, 10, 64)
+			if err == nil {
+				msg.ByTask[id] = u
+			}
+		}
+		today := time.Now().UTC().Format("2006-01-02")
+		if len(byDay) > 0 && byDay[0].Key == today {
+			msg.Today = byDay[0].Usage
+		}
+		return msg
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Cursor movement helpers
 // ---------------------------------------------------------------------------
@@ -412,6 +455,9 @@ func (d *DashboardScreen) updateDetailText() {
 	b.WriteString(fmt.Sprintf("Mode: %s\n", t.Mode))
 	b.WriteString(fmt.Sprintf("Thread: %s\n", d.threadName(t.ThreadID)))
 	b.WriteString(fmt.Sprintf("Created: %s\n", t.CreatedAt.Format("2006-01-02 15:04")))
+	if u, ok := d.usageByTask[t.ID]; ok {
+		b.WriteString(fmt.Sprintf("Cost: %s (%d runs)\n", formatUsage(u.Usage), u.Runs))
+	}
 	b.WriteString("\n--- Prompt ---\n")
 	b.WriteString(t.Prompt)
 
@@ -652,7 +698,7 @@ func (d *DashboardScreen) renderBottomBar(totalWidth int) string {
 	execCount := len(d.executions)
 	runningCount := 0
 	for _, e := range d.executions {
-		if e.Status == db.StatusRunning {
+		if e.Status == db.StatusRunning || e.Status == db.StatusAwaitingUser {
 			runningCount++
 		}
 	}
@@ -662,8 +708,8 @@ func (d *DashboardScreen) renderBottomBar(totalWidth int) string {
 		maxWorkers = d.app.Config().Agents.MaxTotalWorkers
 	}
 
-	info := fmt.Sprintf(" %s | Tasks: %d | Exec: %d | Running: %d/%d ",
-		clusterName, len(d.tasks), execCount, runningCount, maxWorkers)
+	info := fmt.Sprintf(" %s | Tasks: %d | Exec: %d | Running: %d/%d | Today: %s ",
+		clusterName, len(d.tasks), execCount, runningCount, maxWorkers, formatCost(d.usageToday.CostUSD))
 
 	keys := " tab:pane  n:task  c:crews  x:commander  r:refresh "
 
@@ -688,8 +734,15 @@ func (d *DashboardScreen) statusBadge(status string) string {
 		return d.styles.BadgeCompleted.Render("DONE")
 	case db.StatusFailed:
 		return d.styles.BadgeFailed.Render("FAIL")
+	case db.StatusAwaitingUser:
+		return d.styles.BadgeInterrupted.Render("ASK")
 	case db.StatusInterrupted:
 		return d.styles.BadgeInterrupted.Render("INT")
+	case db.StatusCancelled:
+		return lipgloss.NewStyle().
+			Foreground(theme.ColorTextSecondary).
+			Padding(0, 1).
+			Render("CNCL")
 	case db.StatusPending:
 		return lipgloss.NewStyle().
 			Foreground(theme.ColorTextSecondary).
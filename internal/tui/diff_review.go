@@ -7,6 +7,7 @@ import (
 
 	"bore-tui/internal/app"
 	"bore-tui/internal/db"
+	"bore-tui/internal/engine"
 	"bore-tui/internal/theme"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -27,6 +28,7 @@ const (
 // DiffReviewScreen shows git status and diff for a completed execution's worktree.
 type DiffReviewScreen struct {
 	app    *app.App
+	eng    *engine.Engine
 	styles theme.Styles
 
 	execution *db.Execution
@@ -49,10 +51,11 @@ type DiffReviewScreen struct {
 }
 
 // NewDiffReviewScreen creates a new DiffReviewScreen.
-func NewDiffReviewScreen(a *app.App, styles theme.Styles) DiffReviewScreen {
+func NewDiffReviewScreen(a *app.App, eng *engine.Engine, styles theme.Styles) DiffReviewScreen {
 	vp := viewport.New(0, 0)
 	return DiffReviewScreen{
 		app:      a,
+		eng:      eng,
 		styles:   styles,
 		viewport: vp,
 	}
@@ -254,52 +257,26 @@ func (s *DiffReviewScreen) executeAction(action int) tea.Cmd {
 	exec := s.execution
 	switch action {
 	case diffActionMerge:
-		return s.mergeChanges(a, exec)
+		return s.mergeChanges(exec)
 	case diffActionCommit:
 		return s.commitChanges(a, exec)
 	case diffActionKeep:
 		return s.keepChanges()
 	case diffActionRevert:
-		return s.revertChanges(a, exec)
+		return s.revertChanges(exec)
 	case diffActionDelete:
 		return s.deleteWorktree(a, exec)
 	}
 	return nil
 }
 
-func (s *DiffReviewScreen) mergeChanges(a *app.App, exec *db.Execution) tea.Cmd {
+func (s *DiffReviewScreen) mergeChanges(exec *db.Execution) tea.Cmd {
+	eng := s.eng
 	return func() tea.Msg {
-		ctx := context.Background()
-
-		// Stage and commit in the worktree.
-		if err := a.Repo().AddAll(ctx, exec.WorktreePath); err != nil {
-			return ErrorMsg{Err: fmt.Errorf("git add: %w", err)}
-		}
-		commitMsg := fmt.Sprintf("bore-tui: execution #%d", exec.ID)
-		if err := a.Repo().Commit(ctx, exec.WorktreePath, commitMsg); err != nil {
-			return ErrorMsg{Err: fmt.Errorf("git commit: %w", err)}
-		}
-
-		// Merge the exec branch into the base branch.
-		baseBranch := exec.BaseBranch
-		if baseBranch == "" {
-			baseBranch = "main"
-		}
-		if err := a.Repo().MergeInto(ctx, baseBranch, exec.ExecBranch); err != nil {
-			return ErrorMsg{Err: fmt.Errorf("git merge: %w", err)}
-		}
-
-		// Clean up: remove worktree and delete the exec branch.
-		if err := a.Repo().RemoveWorktree(ctx, exec.WorktreePath); err != nil {
-			return ErrorMsg{Err: fmt.Errorf("remove worktree: %w", err)}
+		baseBranch, err := eng.Merge(context.Background(), exec.ID)
+		if err != nil {
+			return ErrorMsg{Err: err}
 		}
-		_ = a.Repo().DeleteBranch(ctx, exec.ExecBranch) // best-effort
-		_ = a.Repo().PruneWorktrees(ctx)
-
-		// Mark execution and task as completed.
-		_ = a.DB().UpdateExecutionStatus(ctx, exec.ID, db.StatusCompleted)
-		_ = a.DB().UpdateTaskStatus(ctx, exec.TaskID, db.StatusCompleted)
-
 		return diffActionDoneMsg{Message: fmt.Sprintf(
 			"Merged %s into %s.\nWorktree and branch cleaned up.",
 			exec.ExecBranch, baseBranch,
@@ -340,18 +317,12 @@ func (s *DiffReviewScreen) keepChanges() tea.Cmd {
 	}
 }
 
-func (s *DiffReviewScreen) revertChanges(a *app.App, exec *db.Execution) tea.Cmd {
+func (s *DiffReviewScreen) revertChanges(exec *db.Execution) tea.Cmd {
+	eng := s.eng
 	return func() tea.Msg {
-		ctx := context.Background()
-
-		if err := a.Repo().Revert(ctx, exec.WorktreePath, true); err != nil {
-			return ErrorMsg{Err: fmt.Errorf("git revert: %w", err)}
+		if err := eng.Revert(context.Background(), exec.ID); err != nil {
+			return ErrorMsg{Err: err}
 		}
-
-		// Mark execution and task as interrupted (reverted).
-		_ = a.DB().UpdateExecutionStatus(ctx, exec.ID, db.StatusInterrupted)
-		_ = a.DB().UpdateTaskStatus(ctx, exec.TaskID, db.StatusInterrupted)
-
 		return diffActionDoneMsg{Message: "All changes have been reverted."}
 	}
 }
@@ -4,73 +4,53 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"bore-tui/internal/agents"
 	"bore-tui/internal/app"
 	"bore-tui/internal/db"
+	"bore-tui/internal/engine"
 	"bore-tui/internal/theme"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 // ---------------------------------------------------------------------------
-// Execution step constants
-// ---------------------------------------------------------------------------
-
-const (
-	execStepIdle           = 0
-	execStepBossPlan       = 1
-	execStepRunningWorkers = 2
-	execStepBossSummary    = 3
-	execStepDone           = 4
-)
-
-// ---------------------------------------------------------------------------
-// Internal message types for phased execution
+// Internal message types
 // ---------------------------------------------------------------------------
 
 type taskLoadedMsg struct{ Task *db.Task }
-type executionOutputMsg struct{ Line string }
 type agentRunsLoadedMsg struct{ Runs []db.AgentRun }
+type executionReloadedMsg struct{ Execution *db.Execution }
+type questionsLoadedMsg struct{ Questions []db.ExecutionQuestion }
 
-type bossPlanDoneMsg struct {
-	plan *agents.BossPlan
-	err  error
-}
-
-type workerDoneMsg struct {
-	result   agents.WorkerResult
-	agentRun *db.AgentRun
-	err      error
-}
+// maxOutputLines bounds the Output tab's buffer of streamed agent output.
+const maxOutputLines = 2000
 
-type bossSummaryDoneMsg struct {
-	summary *agents.BossSummary
-	err     error
-}
-
-// executionStartedInternalMsg signals that execution has been marked started
-// in the DB and the boss plan phase should begin.
-type executionStartedInternalMsg struct {
-	task *db.Task
-	err  error
-}
+// executionStartFailedMsg reports that the engine refused to start or resume
+// the execution (e.g. it was no longer pending or stopped).
+type executionStartFailedMsg struct{ err error }
 
 // ---------------------------------------------------------------------------
 // ExecutionViewScreen
 // ---------------------------------------------------------------------------
 
-// ExecutionViewScreen shows a running or completed execution.
+// ExecutionViewScreen shows a running or completed execution. The execution
+// itself is driven by the engine; this screen only starts it and renders the
+// engine's events, so runs continue when the user navigates away.
 type ExecutionViewScreen struct {
 	app    *app.App
+	engine *engine.Engine
 	styles theme.Styles
 
 	execution *db.Execution
 	task      *db.Task
-	brief     *agents.ExecutionBrief
 	agentRuns []db.AgentRun
+	// activeRuns holds the IDs of agent runs the engine reports in flight.
+	activeRuns map[int64]bool
 
 	// Live output
 	outputLines []string
@@ -79,12 +59,19 @@ type ExecutionViewScreen struct {
 	// Tab: 0=overview, 1=live output, 2=workers
 	tab int
 
-	// Phased execution state
-	execStep      int // execStepIdle..execStepDone
-	bossPlan      *agents.BossPlan
-	workerResults []agents.WorkerResult
-	currentWorker int
-	crew          *db.Crew // cached crew for the execution
+	// Engine progress, mirrored from engine events.
+	step         string
+	workersTotal int
+	workersDone  int
+	paused       bool
+
+	// Open questions escalated to the user (alert_with_issues mode).
+	questions   []db.ExecutionQuestion
+	answering   bool
+	answerInput textinput.Model
+
+	// confirmCancel is set after the first "x" press; a second press cancels.
+	confirmCancel bool
 
 	// State
 	running       bool
@@ -93,12 +80,18 @@ type ExecutionViewScreen struct {
 }
 
 // NewExecutionViewScreen creates a new ExecutionViewScreen.
-func NewExecutionViewScreen(a *app.App, styles theme.Styles) ExecutionViewScreen {
+func NewExecutionViewScreen(a *app.App, eng *engine.Engine, styles theme.Styles) ExecutionViewScreen {
 	vp := viewport.New(0, 0)
+	ti := textinput.New()
+	ti.Placeholder = "Your answer (empty accepts the worker's result)..."
+	ti.CharLimit = 2000
+	ti.Prompt = "> "
 	return ExecutionViewScreen{
-		app:      a,
-		styles:   styles,
-		viewport: vp,
+		app:         a,
+		engine:      eng,
+		styles:      styles,
+		viewport:    vp,
+		answerInput: ti,
 	}
 }
 
@@ -107,46 +100,106 @@ func (s ExecutionViewScreen) Init() tea.Cmd {
 	return nil
 }
 
-// SetExecution configures the screen for a specific execution and returns the
-// command to start or load it.
-func (s *ExecutionViewScreen) SetExecution(exec *db.Execution) tea.Cmd {
+// reset clears all per-execution state.
+func (s *ExecutionViewScreen) reset(exec *db.Execution, task *db.Task) {
 	s.execution = exec
-	s.task = nil
-	s.brief = nil
+	s.task = task
 	s.tab = 0
 	s.err = nil
 	s.outputLines = nil
 	s.agentRuns = nil
-	s.execStep = execStepIdle
-	s.bossPlan = nil
-	s.workerResults = nil
-	s.currentWorker = 0
-	s.crew = nil
+	s.activeRuns = nil
+	s.step = ""
+	s.workersTotal = 0
+	s.workersDone = 0
+	s.paused = false
+	s.running = false
+	s.questions = nil
+	s.answering = false
+	s.answerInput.Reset()
+	s.answerInput.Blur()
+	s.confirmCancel = false
+}
+
+// SetExecution configures the screen for a specific execution and returns the
+// command to start or load it.
+func (s *ExecutionViewScreen) SetExecution(exec *db.Execution) tea.Cmd {
+	s.reset(exec, nil)
+
+	var start tea.Cmd
+	if exec.Status == db.StatusPending {
+		start = s.startExecution(nil)
+	} else {
+		s.attach()
+	}
 
 	// Load the associated task.
 	return tea.Batch(
 		s.loadTask(),
-		s.maybeStartExecution(),
+		s.loadAgentRuns(),
+		s.loadQuestions(),
+		start,
 	)
 }
 
 // SetExecutionWithBrief configures the screen with an execution, brief, and
 // task so the brief is available for the boss plan phase.
 func (s *ExecutionViewScreen) SetExecutionWithBrief(exec *db.Execution, brief agents.ExecutionBrief, task *db.Task) tea.Cmd {
-	s.execution = exec
-	s.task = task
-	s.brief = &brief
-	s.tab = 0
+	s.reset(exec, task)
+	return s.startExecution(&brief)
+}
+
+// startExecution hands a pending execution to the engine.
+func (s *ExecutionViewScreen) startExecution(brief *agents.ExecutionBrief) tea.Cmd {
+	eng := s.engine
+	execID := s.execution.ID
+	s.running = true
+	s.step = engine.StepStarting
+	return func() tea.Msg {
+		var err error
+		if brief != nil {
+			err = eng.StartWithBrief(execID, *brief)
+		} else {
+			err = eng.Start(execID)
+		}
+		if err != nil {
+			return executionStartFailedMsg{err: fmt.Errorf("start execution: %w", err)}
+		}
+		return nil
+	}
+}
+
+// resumeExecution hands an interrupted or cancelled execution back to the
+// engine.
+func (s *ExecutionViewScreen) resumeExecution() tea.Cmd {
+	eng := s.engine
+	execID := s.execution.ID
+	s.running = true
 	s.err = nil
-	s.outputLines = nil
-	s.agentRuns = nil
-	s.execStep = execStepIdle
-	s.bossPlan = nil
-	s.workerResults = nil
-	s.currentWorker = 0
-	s.crew = nil
+	s.step = engine.StepStarting
+	s.workersTotal = 0
+	s.workersDone = 0
+	return func() tea.Msg {
+		if err := eng.Resume(execID); err != nil {
+			return executionStartFailedMsg{err: fmt.Errorf("resume execution: %w", err)}
+		}
+		return nil
+	}
+}
 
-	return s.maybeStartExecution()
+// attach copies the engine's snapshot of an in-flight execution into the
+// screen so returning to a running execution shows its progress so far.
+func (s *ExecutionViewScreen) attach() {
+	snap, ok := s.engine.Snapshot(s.execution.ID)
+	if !ok {
+		return
+	}
+	s.running = true
+	s.paused = snap.Paused
+	s.step = snap.Step
+	s.workersTotal = snap.WorkersTotal
+	s.workersDone = snap.WorkersDone
+	s.outputLines = snap.Output
 }
 
 // Update processes messages for the execution view screen.
@@ -157,6 +210,7 @@ func (s ExecutionViewScreen) Update(msg tea.Msg) (ExecutionViewScreen, tea.Cmd)
 		s.height = msg.Height
 		s.viewport.Width = msg.Width - 4
 		s.viewport.Height = msg.Height - 10
+		s.answerInput.Width = msg.Width - 10
 		s.updateViewportContent()
 		return s, nil
 
@@ -165,114 +219,37 @@ func (s ExecutionViewScreen) Update(msg tea.Msg) (ExecutionViewScreen, tea.Cmd)
 		s.updateViewportContent()
 		return s, nil
 
-	case executionStartedInternalMsg:
-		if msg.err != nil {
-			s.running = false
-			s.err = msg.err
-			s.updateViewportContent()
-			return s, nil
-		}
-		// Store the task if it was loaded in the start phase.
-		if msg.task != nil && s.task == nil {
-			s.task = msg.task
-		}
-		s.execStep = execStepBossPlan
-		s.outputLines = append(s.outputLines, "Execution started. Running Boss plan...")
+	case executionReloadedMsg:
+		s.execution = msg.Execution
 		s.updateViewportContent()
-		return s, s.runBossPlan()
-
-	case executionOutputMsg:
-		s.outputLines = append(s.outputLines, msg.Line)
-		if s.tab == 1 {
-			s.updateViewportContent()
-			s.viewport.GotoBottom()
-		}
 		return s, nil
 
-	case bossPlanDoneMsg:
-		if msg.err != nil {
-			s.running = false
-			s.err = msg.err
-			s.updateViewportContent()
-			return s, nil
-		}
-		s.bossPlan = msg.plan
-		s.execStep = execStepRunningWorkers
-		s.currentWorker = 0
-		s.workerResults = nil
-		s.outputLines = append(s.outputLines,
-			fmt.Sprintf("Boss plan complete: %d steps, %d workers needed.",
-				len(msg.plan.Steps), len(msg.plan.NeedsWorkers)))
-		s.updateViewportContent()
-
-		// Start the first worker, or skip to summary if no workers needed.
-		if len(s.bossPlan.NeedsWorkers) == 0 {
-			s.execStep = execStepBossSummary
-			s.outputLines = append(s.outputLines, "No workers needed. Running Boss summary...")
-			s.updateViewportContent()
-			return s, s.runBossSummary()
-		}
-		s.outputLines = append(s.outputLines,
-			fmt.Sprintf("Starting worker 1/%d: %s...",
-				len(s.bossPlan.NeedsWorkers), s.bossPlan.NeedsWorkers[0].Role))
+	case executionStartFailedMsg:
+		s.running = false
+		s.err = msg.err
 		s.updateViewportContent()
-		return s, s.runNextWorker(s.bossPlan.NeedsWorkers[0])
-
-	case workerDoneMsg:
-		if msg.err != nil {
-			s.outputLines = append(s.outputLines,
-				fmt.Sprintf("Worker error: %v", msg.err))
-		} else {
-			s.workerResults = append(s.workerResults, msg.result)
-			s.outputLines = append(s.outputLines,
-				fmt.Sprintf("Worker %q finished: %s", msg.result.Summary, msg.result.Outcome))
-		}
+		return s, s.reloadExecution()
 
-		// Reload agent runs to show in the workers tab.
-		reloadCmd := s.loadAgentRuns()
-
-		s.currentWorker++
-		if s.currentWorker < len(s.bossPlan.NeedsWorkers) {
-			// Start the next worker.
-			need := s.bossPlan.NeedsWorkers[s.currentWorker]
-			s.outputLines = append(s.outputLines,
-				fmt.Sprintf("Starting worker %d/%d: %s...",
-					s.currentWorker+1, len(s.bossPlan.NeedsWorkers), need.Role))
-			s.updateViewportContent()
-			return s, tea.Batch(reloadCmd, s.runNextWorker(need))
-		}
-
-		// All workers done; start boss summary.
-		s.execStep = execStepBossSummary
-		s.outputLines = append(s.outputLines, "All workers complete. Running Boss summary...")
-		s.updateViewportContent()
-		return s, tea.Batch(reloadCmd, s.runBossSummary())
+	case EngineEventMsg:
+		return s.handleEngineEvent(msg.Event)
 
-	case bossSummaryDoneMsg:
-		s.execStep = execStepDone
-		s.running = false
-		if msg.err != nil {
-			s.outputLines = append(s.outputLines,
-				fmt.Sprintf("Boss summary error: %v", msg.err))
-		} else if msg.summary != nil {
-			s.outputLines = append(s.outputLines,
-				fmt.Sprintf("Execution finished: %s", msg.summary.Outcome))
-		} else {
-			s.outputLines = append(s.outputLines, "Execution finished.")
+	case questionsLoadedMsg:
+		s.questions = msg.Questions
+		if len(s.questions) == 0 {
+			s.answering = false
+			s.answerInput.Blur()
 		}
-		s.updateViewportContent()
-		// Reload agent runs and update execution status from DB.
-		return s, s.loadAgentRuns()
-
-	case ExecutionDoneMsg:
-		s.running = false
-		s.execution.Status = msg.Status
-		s.updateViewportContent()
-		// Reload agent runs.
-		return s, s.loadAgentRuns()
+		return s, nil
 
 	case agentRunsLoadedMsg:
 		s.agentRuns = msg.Runs
+		s.activeRuns = nil
+		if snap, ok := s.engine.Snapshot(s.execution.ID); ok {
+			s.activeRuns = make(map[int64]bool, len(snap.ActiveRuns))
+			for _, id := range snap.ActiveRuns {
+				s.activeRuns[id] = true
+			}
+		}
 		s.updateViewportContent()
 		return s, nil
 
@@ -295,6 +272,85 @@ func (s ExecutionViewScreen) Update(msg tea.Msg) (ExecutionViewScreen, tea.Cmd)
 	return s, cmd
 }
 
+// handleEngineEvent applies an engine event for the displayed execution.
+// Events for other executions are ignored.
+func (s ExecutionViewScreen) handleEngineEvent(ev engine.Event) (ExecutionViewScreen, tea.Cmd) {
+	if s.execution == nil || ev.ExecutionID != s.execution.ID {
+		return s, nil
+	}
+
+	switch ev.Kind {
+	case engine.EventStarted:
+		s.running = true
+		return s, s.reloadExecution()
+
+	case engine.EventStep:
+		s.step = ev.Step
+		if ev.Step == engine.StepWorkers {
+			if snap, ok := s.engine.Snapshot(s.execution.ID); ok {
+				s.workersTotal = snap.WorkersTotal
+			}
+		}
+
+	case engine.EventOutput:
+		s.appendOutput(ev.Message)
+		return s, nil
+
+	case engine.EventAgentOutput:
+		s.appendOutput(fmt.Sprintf("[%s] %s", ev.Agent, ev.Message))
+		return s, nil
+
+	case engine.EventAgentStarted:
+		// Show the new in-flight run in the workers tab.
+		return s, s.loadAgentRuns()
+
+	case engine.EventWorkerDone:
+		if snap, ok := s.engine.Snapshot(s.execution.ID); ok {
+			s.workersTotal = snap.WorkersTotal
+			s.workersDone = snap.WorkersDone
+		}
+		// Reload agent runs to show in the workers tab.
+		return s, s.loadAgentRuns()
+
+	case engine.EventPaused:
+		s.paused = true
+
+	case engine.EventUnpaused:
+		s.paused = false
+
+	case engine.EventQuestion, engine.EventAnswered:
+		return s, s.loadQuestions()
+
+	case engine.EventFinished:
+		s.running = false
+		s.paused = false
+		s.step = engine.StepDone
+		s.execution.Status = ev.Status
+		s.questions = nil
+		s.answering = false
+		s.answerInput.Blur()
+		s.updateViewportContent()
+		// Reload agent runs and update execution status from DB.
+		return s, tea.Batch(s.loadAgentRuns(), s.reloadExecution())
+	}
+
+	s.updateViewportContent()
+	return s, nil
+}
+
+// appendOutput adds a line to the Output tab, keeping it scrolled to the
+// bottom while it is visible.
+func (s *ExecutionViewScreen) appendOutput(line string) {
+	s.outputLines = append(s.outputLines, line)
+	if len(s.outputLines) > maxOutputLines {
+		s.outputLines = s.outputLines[len(s.outputLines)-maxOutputLines:]
+	}
+	if s.tab == 1 {
+		s.updateViewportContent()
+		s.viewport.GotoBottom()
+	}
+}
+
 func (s ExecutionViewScreen) handleMouse(msg tea.MouseMsg) (ExecutionViewScreen, tea.Cmd) {
 	// Scroll wheel: forward to viewport.
 	if tea.MouseEvent(msg).IsWheel() {
@@ -333,10 +389,49 @@ func (s ExecutionViewScreen) handleMouse(msg tea.MouseMsg) (ExecutionViewScreen,
 func (s ExecutionViewScreen) handleKey(msg tea.KeyMsg) (ExecutionViewScreen, tea.Cmd) {
 	key := msg.String()
 
+	if s.answering {
+		switch key {
+		case "esc":
+			s.answering = false
+			s.answerInput.Blur()
+			return s, nil
+		case "enter":
+			if len(s.questions) == 0 {
+				s.answering = false
+				s.answerInput.Blur()
+				return s, nil
+			}
+			answer := strings.TrimSpace(s.answerInput.Value())
+			s.answering = false
+			s.answerInput.Reset()
+			s.answerInput.Blur()
+			return s, s.answerQuestion(s.questions[0].ID, answer)
+		}
+		var cmd tea.Cmd
+		s.answerInput, cmd = s.answerInput.Update(msg)
+		return s, cmd
+	}
+
+	if key != "x" {
+		s.confirmCancel = false
+	}
+
 	switch key {
 	case "esc":
 		return s, func() tea.Msg { return NavigateBackMsg{} }
 
+	case "x":
+		// Cancel a running execution; requires a second press to confirm.
+		if !s.running || s.execution == nil {
+			return s, nil
+		}
+		if !s.confirmCancel {
+			s.confirmCancel = true
+			return s, nil
+		}
+		s.confirmCancel = false
+		return s, s.cancelExecution()
+
 	case "tab":
 		s.tab = (s.tab + 1) % 3
 		s.updateViewportContent()
@@ -360,6 +455,31 @@ func (s ExecutionViewScreen) handleKey(msg tea.KeyMsg) (ExecutionViewScreen, tea
 	case "r":
 		// Refresh agent runs.
 		return s, s.loadAgentRuns()
+
+	case "R":
+		// Resume a stopped execution from its last completed worker.
+		if !s.running && s.execution != nil &&
+			(s.execution.Status == db.StatusInterrupted || s.execution.Status == db.StatusCancelled) {
+			return s, s.resumeExecution()
+		}
+		return s, nil
+
+	case "a":
+		// Answer the oldest open question.
+		if s.running && len(s.questions) > 0 {
+			s.answering = true
+			s.answerInput.Reset()
+			s.answerInput.Placeholder = answerPlaceholder(s.questions[0].Kind)
+			return s, s.answerInput.Focus()
+		}
+		return s, nil
+
+	case "p":
+		// Toggle pause on a running execution.
+		if s.running && s.execution != nil {
+			return s, s.togglePause()
+		}
+		return s, nil
 	}
 
 	// Forward to viewport for scrolling.
@@ -398,348 +518,83 @@ func (s *ExecutionViewScreen) loadAgentRuns() tea.Cmd {
 	}
 }
 
-func (s *ExecutionViewScreen) maybeStartExecution() tea.Cmd {
-	exec := s.execution
-	if exec == nil {
-		return nil
-	}
-
-	// Only start if execution is still pending.
-	if exec.Status != db.StatusPending {
-		// Already running or finished; just load agent runs.
-		if exec.Status == db.StatusRunning {
-			s.running = true
-		}
-		return s.loadAgentRuns()
-	}
-
-	s.running = true
-	return s.startExecution()
-}
-
-// startExecution marks the execution as started in the DB and returns a
-// message that triggers the boss plan phase via Update.
-func (s *ExecutionViewScreen) startExecution() tea.Cmd {
+func (s *ExecutionViewScreen) reloadExecution() tea.Cmd {
 	a := s.app
-	exec := s.execution
-	task := s.task
-
+	execID := s.execution.ID
 	return func() tea.Msg {
 		ctx := context.Background()
-
-		// Mark execution as started.
-		if err := a.DB().SetExecutionStarted(ctx, exec.ID); err != nil {
-			return executionStartedInternalMsg{err: fmt.Errorf("set execution started: %w", err)}
+		exec, err := a.DB().GetExecution(ctx, execID)
+		if err != nil {
+			return ErrorMsg{Err: fmt.Errorf("reload execution: %w", err)}
 		}
-
-		_ = a.DB().CreateEvent(ctx, exec.ID, db.LevelInfo, "execution_start", "Execution started")
-
-		// If task was not passed in (e.g. navigated from dashboard), load it.
-		localTask := task
-		if localTask == nil {
-			var err error
-			localTask, err = a.DB().GetTask(ctx, exec.TaskID)
-			if err != nil {
-				markFailed(ctx, a, exec.ID, nil)
-				return executionStartedInternalMsg{err: fmt.Errorf("load task for execution: %w", err)}
-			}
+		if exec == nil {
+			return nil
 		}
-
-		return executionStartedInternalMsg{task: localTask}
+		return executionReloadedMsg{Execution: exec}
 	}
 }
 
-// runBossPlan runs JUST the Boss plan phase and returns a bossPlanDoneMsg.
-func (s *ExecutionViewScreen) runBossPlan() tea.Cmd {
+// loadQuestions fetches the execution's open questions.
+func (s *ExecutionViewScreen) loadQuestions() tea.Cmd {
 	a := s.app
-	exec := s.execution
-	task := s.task
-	brief := s.brief
+	execID := s.execution.ID
 	return func() tea.Msg {
-		ctx := context.Background()
-
-		// Load task if not available yet.
-		localTask := task
-		if localTask == nil {
-			var err error
-			localTask, err = a.DB().GetTask(ctx, exec.TaskID)
-			if err != nil {
-				markFailed(ctx, a, exec.ID, nil)
-				return bossPlanDoneMsg{err: fmt.Errorf("load task: %w", err)}
-			}
-		}
-
-		// Load crew if assigned.
-		var crew *db.Crew
-		if exec.CrewID != nil {
-			var err error
-			crew, err = a.DB().GetCrew(ctx, *exec.CrewID)
-			if err != nil {
-				markFailed(ctx, a, exec.ID, localTask)
-				return bossPlanDoneMsg{err: fmt.Errorf("load crew: %w", err)}
-			}
-		}
-
-		_ = a.DB().CreateEvent(ctx, exec.ID, db.LevelInfo, "boss_plan", "Running Boss plan phase")
-
-		// Build the brief.
-		var useBrief agents.ExecutionBrief
-		if brief != nil {
-			useBrief = *brief
-		} else {
-			useBrief = buildBriefFromExec(exec, localTask)
-		}
-
-		bossCtx := agents.BossContext{
-			Crew:         crew,
-			Brief:        useBrief,
-			TaskPrompt:   localTask.Prompt,
-			Mode:         localTask.Mode,
-			WorkerBudget: 3, // Default budget for V1.
-		}
-
-		bossSystemPrompt := agents.BuildBossSystemPrompt(bossCtx)
-		bossPlanPrompt := agents.BuildBossPlanPrompt(bossCtx)
-		fullBossPrompt := bossSystemPrompt + "\n\n" + bossPlanPrompt
-
-		bossResult := a.Runner().Run(ctx, exec.WorktreePath, fullBossPrompt, nil, nil, nil)
-		if bossResult.Err != nil {
-			markFailed(ctx, a, exec.ID, localTask)
-			return bossPlanDoneMsg{err: fmt.Errorf("boss plan: %w", bossResult.Err)}
-		}
-
-		if bossResult.JSONBlock == "" {
-			markFailed(ctx, a, exec.ID, localTask)
-			return bossPlanDoneMsg{err: fmt.Errorf("boss plan: no JSON response")}
-		}
-
-		parsed, err := agents.ParseResponse(bossResult.JSONBlock)
+		all, err := a.DB().ListQuestions(context.Background(), execID)
 		if err != nil {
-			markFailed(ctx, a, exec.ID, localTask)
-			return bossPlanDoneMsg{err: fmt.Errorf("boss plan parse: %w", err)}
+			return ErrorMsg{Err: fmt.Errorf("load questions: %w", err)}
 		}
-
-		plan, ok := parsed.(agents.BossPlan)
-		if !ok {
-			markFailed(ctx, a, exec.ID, localTask)
-			return bossPlanDoneMsg{err: fmt.Errorf("boss plan: unexpected type %T", parsed)}
+		var open []db.ExecutionQuestion
+		for _, q := range all {
+			if q.Status == db.QuestionOpen {
+				open = append(open, q)
+			}
 		}
-
-		// Save boss plan as an agent run.
-		_, _ = a.DB().CreateAgentRun(ctx, exec.ID, db.AgentTypeBoss, "planner",
-			fullBossPrompt, fmt.Sprintf("Plan with %d steps, %d workers", len(plan.Steps), len(plan.NeedsWorkers)),
-			db.OutcomeSuccess, strings.Join(plan.EstimatedFiles, ", "))
-
-		_ = a.DB().CreateEvent(ctx, exec.ID, db.LevelInfo, "boss_plan_done",
-			fmt.Sprintf("Boss plan: %d steps, %d workers needed", len(plan.Steps), len(plan.NeedsWorkers)))
-
-		return bossPlanDoneMsg{plan: &plan}
+		return questionsLoadedMsg{Questions: open}
 	}
 }
 
-// runNextWorker runs a single worker and returns a workerDoneMsg.
-func (s *ExecutionViewScreen) runNextWorker(workerNeed agents.WorkerNeed) tea.Cmd {
-	a := s.app
-	exec := s.execution
-	crew := s.crew
-	workerIdx := s.currentWorker
-	totalWorkers := len(s.bossPlan.NeedsWorkers)
+// answerQuestion sends the user's answer to the engine, which unblocks the
+// waiting worker and publishes EventAnswered.
+func (s *ExecutionViewScreen) answerQuestion(id int64, answer string) tea.Cmd {
+	eng := s.engine
 	return func() tea.Msg {
-		ctx := context.Background()
-
-		_ = a.DB().CreateEvent(ctx, exec.ID, db.LevelInfo, "worker_start",
-			fmt.Sprintf("Starting worker %d/%d: %s", workerIdx+1, totalWorkers, workerNeed.Role))
-
-		// Acquire scheduler slot.
-		if err := a.Scheduler().Acquire(ctx); err != nil {
-			_ = a.DB().CreateEvent(ctx, exec.ID, db.LevelError, "scheduler_error", err.Error())
-			return workerDoneMsg{err: fmt.Errorf("scheduler: %w", err)}
-		}
-
-		workerCtx := agents.WorkerContext{
-			Role:            workerNeed.Role,
-			Goal:            workerNeed.Goal,
-			FilesOrPaths:    workerNeed.FilesOrPaths,
-			AllowedCommands: workerNeed.Commands,
-			SuccessCriteria: workerNeed.SuccessCriteria,
+		if err := eng.Answer(id, answer); err != nil {
+			return ErrorMsg{Err: err}
 		}
-		if crew != nil {
-			workerCtx.CrewObjective = crew.Objective
-			workerCtx.CrewConstraints = crew.Constraints
-		}
-
-		workerPrompt := agents.BuildWorkerSystemPrompt(workerCtx)
-		workerResult := a.Runner().Run(ctx, exec.WorktreePath, workerPrompt, nil, nil, nil)
-
-		a.Scheduler().Release()
-
-		if workerResult.Err != nil {
-			_ = a.DB().CreateEvent(ctx, exec.ID, db.LevelError, "worker_error",
-				fmt.Sprintf("Worker %s failed: %v", workerNeed.Role, workerResult.Err))
-
-			_, _ = a.DB().CreateAgentRun(ctx, exec.ID, db.AgentTypeWorker, workerNeed.Role,
-				workerPrompt, fmt.Sprintf("Failed: %v", workerResult.Err),
-				db.OutcomeFailed, "")
-			return workerDoneMsg{err: fmt.Errorf("worker %s: %w", workerNeed.Role, workerResult.Err)}
-		}
-
-		if workerResult.JSONBlock == "" {
-			_ = a.DB().CreateEvent(ctx, exec.ID, db.LevelWarn, "worker_no_json",
-				fmt.Sprintf("Worker %s returned no JSON", workerNeed.Role))
-
-			_, _ = a.DB().CreateAgentRun(ctx, exec.ID, db.AgentTypeWorker, workerNeed.Role,
-				workerPrompt, "No JSON output",
-				db.OutcomeFailed, "")
-			return workerDoneMsg{err: fmt.Errorf("worker %s: no JSON response", workerNeed.Role)}
-		}
-
-		parsedWorker, err := agents.ParseResponse(workerResult.JSONBlock)
-		if err != nil {
-			_ = a.DB().CreateEvent(ctx, exec.ID, db.LevelWarn, "worker_parse_error",
-				fmt.Sprintf("Worker %s parse error: %v", workerNeed.Role, err))
-
-			_, _ = a.DB().CreateAgentRun(ctx, exec.ID, db.AgentTypeWorker, workerNeed.Role,
-				workerPrompt, fmt.Sprintf("Parse error: %v", err),
-				db.OutcomeFailed, "")
-			return workerDoneMsg{err: fmt.Errorf("worker %s parse: %w", workerNeed.Role, err)}
-		}
-
-		wr, ok := parsedWorker.(agents.WorkerResult)
-		if !ok {
-			_ = a.DB().CreateEvent(ctx, exec.ID, db.LevelWarn, "worker_type_error",
-				fmt.Sprintf("Worker %s returned unexpected type %T", workerNeed.Role, parsedWorker))
-			return workerDoneMsg{err: fmt.Errorf("worker %s: unexpected type %T", workerNeed.Role, parsedWorker)}
-		}
-
-		// Save worker run to DB.
-		agentRun, _ := a.DB().CreateAgentRun(ctx, exec.ID, db.AgentTypeWorker, workerNeed.Role,
-			workerPrompt, wr.Summary, wr.Outcome, strings.Join(wr.FilesChanged, ", "))
-
-		_ = a.DB().CreateEvent(ctx, exec.ID, db.LevelInfo, "worker_done",
-			fmt.Sprintf("Worker %s finished: %s", workerNeed.Role, wr.Outcome))
-
-		return workerDoneMsg{result: wr, agentRun: agentRun}
+		return nil
 	}
 }
 
-// runBossSummary runs the Boss summary phase and returns a bossSummaryDoneMsg.
-func (s *ExecutionViewScreen) runBossSummary() tea.Cmd {
-	a := s.app
-	exec := s.execution
-	task := s.task
-	brief := s.brief
-	workerResults := s.workerResults
-
+// cancelExecution asks the engine to stop the execution. The screen updates
+// when the engine publishes EventFinished.
+func (s *ExecutionViewScreen) cancelExecution() tea.Cmd {
+	eng := s.engine
+	execID := s.execution.ID
 	return func() tea.Msg {
-		ctx := context.Background()
-
-		_ = a.DB().CreateEvent(ctx, exec.ID, db.LevelInfo, "boss_summary", "Running Boss summary phase")
-
-		// Load task if needed.
-		localTask := task
-		if localTask == nil {
-			var err error
-			localTask, err = a.DB().GetTask(ctx, exec.TaskID)
-			if err != nil {
-				return bossSummaryDoneMsg{err: fmt.Errorf("load task: %w", err)}
-			}
-		}
-
-		// Load crew if assigned.
-		var crew *db.Crew
-		if exec.CrewID != nil {
-			var err error
-			crew, err = a.DB().GetCrew(ctx, *exec.CrewID)
-			if err != nil {
-				return bossSummaryDoneMsg{err: fmt.Errorf("load crew: %w", err)}
-			}
+		if err := eng.Cancel(execID); err != nil {
+			return ErrorMsg{Err: err}
 		}
+		return nil
+	}
+}
 
-		// Build the brief.
-		var useBrief agents.ExecutionBrief
-		if brief != nil {
-			useBrief = *brief
+// togglePause pauses or unpauses the execution in the engine. The screen
+// updates when the corresponding engine event arrives.
+func (s *ExecutionViewScreen) togglePause() tea.Cmd {
+	eng := s.engine
+	execID := s.execution.ID
+	paused := s.paused
+	return func() tea.Msg {
+		var err error
+		if paused {
+			err = eng.Unpause(execID)
 		} else {
-			useBrief = buildBriefFromExec(exec, localTask)
-		}
-
-		bossCtx := agents.BossContext{
-			Crew:         crew,
-			Brief:        useBrief,
-			TaskPrompt:   localTask.Prompt,
-			Mode:         localTask.Mode,
-			WorkerBudget: 3,
-		}
-
-		bossSystemPrompt := agents.BuildBossSystemPrompt(bossCtx)
-		bossSummaryPrompt := bossSystemPrompt + "\n\n" + agents.BuildBossSummaryPrompt(workerResults)
-		summaryResult := a.Runner().Run(ctx, exec.WorktreePath, bossSummaryPrompt, nil, nil, nil)
-
-		finalStatus := db.StatusCompleted
-		var bossSummary *agents.BossSummary
-
-		if summaryResult.Err != nil {
-			_ = a.DB().CreateEvent(ctx, exec.ID, db.LevelError, "boss_summary_error",
-				fmt.Sprintf("Boss summary failed: %v", summaryResult.Err))
-		} else if summaryResult.JSONBlock != "" {
-			parsedSummary, err := agents.ParseResponse(summaryResult.JSONBlock)
-			if err == nil {
-				if bs, ok := parsedSummary.(agents.BossSummary); ok {
-					bossSummary = &bs
-
-					// Save summary as agent run.
-					_, _ = a.DB().CreateAgentRun(ctx, exec.ID, db.AgentTypeBoss, "summarizer",
-						bossSummaryPrompt, strings.Join(bs.WhatChanged, "; "),
-						bs.Outcome, strings.Join(bs.FilesTouched, ", "))
-
-					// Save lessons.
-					for _, lesson := range bs.Lessons {
-						_ = a.DB().CreateLesson(ctx, exec.ID, db.AgentTypeBoss, lesson.LessonType, lesson.Content)
-					}
-
-					// Set final status based on outcome.
-					switch bs.Outcome {
-					case "failed":
-						finalStatus = db.StatusFailed
-					case "partial":
-						finalStatus = db.StatusDiffReview
-					default:
-						finalStatus = db.StatusDiffReview
-					}
-				}
-			}
+			err = eng.Pause(execID)
 		}
-
-		// Mark execution finished.
-		if err := a.DB().SetExecutionFinished(ctx, exec.ID, finalStatus); err != nil {
-			return bossSummaryDoneMsg{err: fmt.Errorf("set execution finished: %w", err)}
+		if err != nil {
+			return ErrorMsg{Err: err}
 		}
-
-		// Update task status.
-		_ = a.DB().UpdateTaskStatus(ctx, localTask.ID, finalStatus)
-
-		_ = a.DB().CreateEvent(ctx, exec.ID, db.LevelInfo, "execution_done",
-			fmt.Sprintf("Execution finished with status: %s", finalStatus))
-
-		return bossSummaryDoneMsg{summary: bossSummary}
-	}
-}
-
-// markFailed is a helper to mark an execution as failed.
-func markFailed(ctx context.Context, a *app.App, execID int64, task *db.Task) {
-	_ = a.DB().SetExecutionFinished(ctx, execID, db.StatusFailed)
-	if task != nil {
-		_ = a.DB().UpdateTaskStatus(ctx, task.ID, db.StatusFailed)
-	}
-}
-
-// buildBriefFromExec creates a minimal ExecutionBrief from an execution and task.
-// In V1, the brief may not have been fully saved; this provides defaults.
-func buildBriefFromExec(exec *db.Execution, task *db.Task) agents.ExecutionBrief {
-	return agents.ExecutionBrief{
-		Type:       "execution_brief",
-		BaseBranch: exec.BaseBranch,
-		TaskTitle:  task.Title,
+		return nil
 	}
 }
 
@@ -785,12 +640,22 @@ func (s ExecutionViewScreen) View() string {
 	}
 
 	// Running indicator with step detail.
-	if s.running {
+	if s.running || s.step == engine.StepDone {
 		runningStyle := lipgloss.NewStyle().Foreground(theme.ColorTextSecondary).Italic(true)
 		stepLabel := s.execStepLabel()
 		sections = append(sections, runningStyle.Render(stepLabel))
 	}
 
+	if s.confirmCancel {
+		warnStyle := lipgloss.NewStyle().Foreground(theme.ColorAccent).Bold(true)
+		sections = append(sections, warnStyle.Render("Press x again to cancel this execution (running agents are killed; the worktree is kept)."))
+	}
+
+	// Open question escalated by a blocked worker.
+	if s.running && len(s.questions) > 0 {
+		sections = append(sections, s.renderQuestion())
+	}
+
 	// Main content viewport (content is already set via updateViewportContent in Update).
 	sections = append(sections, s.viewport.View())
 
@@ -802,22 +667,65 @@ func (s ExecutionViewScreen) View() string {
 }
 
 func (s ExecutionViewScreen) execStepLabel() string {
-	switch s.execStep {
-	case execStepBossPlan:
-		return "Running Boss plan phase..."
-	case execStepRunningWorkers:
-		if s.bossPlan != nil && len(s.bossPlan.NeedsWorkers) > 0 {
-			return fmt.Sprintf("Running worker %d/%d...",
-				s.currentWorker+1, len(s.bossPlan.NeedsWorkers))
-		}
-		return "Running workers..."
-	case execStepBossSummary:
-		return "Running Boss summary phase..."
-	case execStepDone:
+	var label string
+	switch s.step {
+	case engine.StepBossPlan:
+		label = "Running Boss plan phase..."
+	case engine.StepWorkers:
+		if s.workersTotal > 0 {
+			label = fmt.Sprintf("Running workers: %d/%d finished...", s.workersDone, s.workersTotal)
+		} else {
+			label = "Running workers..."
+		}
+	case engine.StepBossReview:
+		label = "Boss reviewing worker results..."
+	case engine.StepBossSummary:
+		label = "Running Boss summary phase..."
+	case engine.StepAwaitingUser:
+		label = "Waiting for your answer to a worker blocker..."
+	case engine.StepDone:
 		return "Execution complete."
 	default:
-		return "Execution in progress..."
+		label = "Execution in progress..."
 	}
+	if s.paused {
+		label += " (paused)"
+	}
+	return label
+}
+
+// renderQuestion renders the oldest open question and, while answering, the
+// answer input.
+func (s ExecutionViewScreen) renderQuestion() string {
+	q := s.questions[0]
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.ColorAccent)
+	title := fmt.Sprintf("Question #%d from %s", q.ID, q.AgentRole)
+	switch q.Kind {
+	case db.QuestionOwnership:
+		title = fmt.Sprintf("Ownership question #%d about %s", q.ID, q.AgentRole)
+	case db.QuestionBudget:
+		title = fmt.Sprintf("Budget question #%d", q.ID)
+	}
+	if len(s.questions) > 1 {
+		title += fmt.Sprintf(" (%d more waiting)", len(s.questions)-1)
+	}
+	lines := []string{labelStyle.Render(title), q.Question}
+	if s.answering {
+		lines = append(lines, "", s.answerInput.View())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// answerPlaceholder returns the answer input's placeholder for a question
+// of the given kind.
+func answerPlaceholder(kind string) string {
+	switch kind {
+	case db.QuestionOwnership:
+		return `"keep" keeps the changes; anything else reverts them...`
+	case db.QuestionBudget:
+		return `"continue" carries on past the budget; anything else stops...`
+	}
+	return "Your answer (empty accepts the worker's result)..."
 }
 
 func (s ExecutionViewScreen) renderTabBar() string {
@@ -842,8 +750,12 @@ func (s ExecutionViewScreen) renderStatusBadge(status string) string {
 		return s.styles.BadgeCompleted.Render(" COMPLETED ")
 	case db.StatusFailed:
 		return s.styles.BadgeFailed.Render(" FAILED ")
+	case db.StatusAwaitingUser:
+		return s.styles.BadgeInterrupted.Render(" AWAITING USER ")
 	case db.StatusInterrupted:
 		return s.styles.BadgeInterrupted.Render(" INTERRUPTED ")
+	case db.StatusCancelled:
+		return s.styles.TabInactive.Render(" CANCELLED ")
 	case db.StatusDiffReview:
 		return s.styles.BadgeCompleted.Render(" DIFF REVIEW ")
 	case db.StatusPending:
@@ -908,7 +820,7 @@ func (s ExecutionViewScreen) workersContent() string {
 	if len(s.agentRuns) == 0 {
 		if s.running {
 			return lipgloss.NewStyle().Foreground(theme.ColorTextSecondary).Italic(true).
-				Render("Workers will appear here as they complete...")
+				Render("Workers will appear here as they start...")
 		}
 		return "No worker runs recorded."
 	}
@@ -918,15 +830,23 @@ func (s ExecutionViewScreen) workersContent() string {
 	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.ColorPrimary)
 	roleStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.ColorTextPrimary)
 
+	var total db.Usage
+	for _, run := range s.agentRuns {
+		total.Add(run.Usage)
+	}
+	lines = append(lines, labelStyle.Render("Total: ")+formatUsage(total), "")
+
 	for i, run := range s.agentRuns {
 		// Outcome badge.
 		var badge string
-		switch run.Outcome {
-		case db.OutcomeSuccess:
+		switch {
+		case s.activeRuns[run.ID], run.Outcome == db.OutcomeRunning:
+			badge = s.styles.BadgeRunning.Render(" RUNNING ")
+		case run.Outcome == db.OutcomeSuccess:
 			badge = s.styles.BadgeCompleted.Render(" SUCCESS ")
-		case db.OutcomePartial:
+		case run.Outcome == db.OutcomePartial:
 			badge = s.styles.BadgeInterrupted.Render(" PARTIAL ")
-		case db.OutcomeFailed:
+		case run.Outcome == db.OutcomeFailed:
 			badge = s.styles.BadgeFailed.Render(" FAILED ")
 		default:
 			badge = s.styles.TabInactive.Render(fmt.Sprintf(" %s ", strings.ToUpper(run.Outcome)))
@@ -941,21 +861,75 @@ func (s ExecutionViewScreen) workersContent() string {
 		if run.FilesChanged != "" {
 			lines = append(lines, labelStyle.Render("  Files: ")+run.FilesChanged)
 		}
+		if run.DurationMS > 0 {
+			usage := formatUsage(run.Usage)
+			if run.Model != "" {
+				usage = run.Model + " · " + usage
+			}
+			lines = append(lines, labelStyle.Render("  Usage: ")+usage)
+		}
 		lines = append(lines, "")
 	}
 
 	return strings.Join(lines, "\n")
 }
 
+// formatUsage renders cost, tokens and duration on one line, e.g.
+// "$0.42 · 12.3k in / 1.5k out · 1m 5s".
+func formatUsage(u db.Usage) string {
+	in := u.InputTokens + u.CacheCreationTokens + u.CacheReadTokens
+	d := time.Duration(u.DurationMS) * time.Millisecond
+	return fmt.Sprintf("%s · %s in / %s out · %s",
+		formatCost(u.CostUSD), formatTokens(in), formatTokens(u.OutputTokens), d.Round(time.Second))
+}
+
+// formatCost renders a dollar amount, keeping sub-cent costs visible.
+func formatCost(usd float64) string {
+	if usd > 0 && usd < 0.01 {
+		return fmt.Sprintf("$%.4f", usd)
+	}
+	return fmt.Sprintf("$%.2f", usd)
+}
+
+// formatTokens abbreviates a token count: 950, 12.3k, 1.2M.
+func formatTokens(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1e6)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1e3)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
 func (s ExecutionViewScreen) renderFooter() string {
 	var hints []string
+	if s.answering {
+		hints = append(hints, "Enter: send answer", "Esc: cancel")
+		return s.styles.StatusBar.Render(strings.Join(hints, " | "))
+	}
 	hints = append(hints, "Tab: switch tabs")
 	hints = append(hints, "Esc: back")
 
+	if s.running && len(s.questions) > 0 {
+		hints = append(hints, "a: answer question")
+	}
+
+	if s.running {
+		if s.paused {
+			hints = append(hints, "p: unpause")
+		} else {
+			hints = append(hints, "p: pause")
+		}
+		hints = append(hints, "x: cancel")
+	}
+
 	if !s.running && s.execution != nil {
 		switch s.execution.Status {
 		case db.StatusCompleted, db.StatusFailed, db.StatusDiffReview:
 			hints = append(hints, "d: review diff")
+		case db.StatusInterrupted, db.StatusCancelled:
+			hints = append(hints, "R: resume")
 		}
 		hints = append(hints, "r: refresh")
 	}
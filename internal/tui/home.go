@@ -8,6 +8,7 @@ import (
 
 	"bore-tui/internal/app"
 	"bore-tui/internal/db"
+	"bore-tui/internal/engine"
 	"bore-tui/internal/theme"
 	"bore-tui/internal/web"
 
@@ -31,6 +32,7 @@ const (
 // HomeScreen shows the splash / cluster picker.
 type HomeScreen struct {
 	app      *app.App
+	engine   *engine.Engine
 	styles   theme.Styles
 	clusters []db.Cluster
 	cursor   int
@@ -40,9 +42,10 @@ type HomeScreen struct {
 }
 
 // NewHomeScreen creates a new home screen.
-func NewHomeScreen(a *app.App, s theme.Styles) HomeScreen {
+func NewHomeScreen(a *app.App, eng *engine.Engine, s theme.Styles) HomeScreen {
 	return HomeScreen{
 		app:    a,
+		engine: eng,
 		styles: s,
 	}
 }
@@ -220,8 +223,9 @@ func (s *HomeScreen) selectItem() tea.Cmd {
 
 	case homeActionWebGUI:
 		a := s.app
+		eng := s.engine
 		return func() tea.Msg {
-			srv := web.New(a)
+			srv := web.New(a, eng)
 			url, err := srv.Start(context.Background())
 			if err != nil {
 				return WebServerErrorMsg{Err: fmt.Errorf("launch web gui: %w", err)}
@@ -3,6 +3,7 @@ package tui
 import (
 	"bore-tui/internal/agents"
 	"bore-tui/internal/db"
+	"bore-tui/internal/engine"
 )
 
 // Screen identifies which screen the TUI is currently showing.
@@ -98,10 +99,21 @@ type ExecutionDoneMsg struct {
 	Status      string
 }
 
+// EngineEventMsg wraps a progress event published by the execution engine.
+type EngineEventMsg struct {
+	Event engine.Event
+}
+
 // ---------------------------------------------------------------------------
 // Data refresh
 // ---------------------------------------------------------------------------
 
+// DataChangedMsg wraps a data change published on the app's change bus, by
+// this TUI, the web GUI or the engine.
+type DataChangedMsg struct {
+	Change db.Change
+}
+
 // ClustersLoadedMsg carries a freshly loaded list of clusters.
 type ClustersLoadedMsg struct{ Clusters []db.Cluster }
 
@@ -111,6 +123,12 @@ type TasksLoadedMsg struct{ Tasks []db.Task }
 // ExecutionsLoadedMsg carries a freshly loaded list of executions.
 type ExecutionsLoadedMsg struct{ Executions []db.Execution }
 
+// UsageLoadedMsg carries per-task usage rollups and today's total.
+type UsageLoadedMsg struct {
+	ByTask map[int64]db.UsageRollup
+	Today  db.Usage
+}
+
 // CrewsLoadedMsg carries a freshly loaded list of crews.
 type CrewsLoadedMsg struct{ Crews []db.Crew }
 
@@ -5,6 +5,7 @@ import (
 
 	"bore-tui/internal/app"
 	"bore-tui/internal/db"
+	"bore-tui/internal/engine"
 	"bore-tui/internal/theme"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,10 +14,16 @@ import (
 
 // Model is the central Bubble Tea model that dispatches to screen models.
 type Model struct {
-	app    *app.App
-	styles theme.Styles
-	keys   KeyMap
-	help   HelpModel
+	app     *app.App
+	engine  *engine.Engine
+	events  <-chan engine.Event
+	changes <-chan db.Change
+	styles  theme.Styles
+	keys    KeyMap
+	help    HelpModel
+
+	unsubscribeEvents  func()
+	unsubscribeChanges func()
 
 	screen      Screen
 	screenStack []Screen
@@ -44,18 +51,27 @@ type Model struct {
 // ---------------------------------------------------------------------------
 
 // NewModel creates the top-level TUI model with default styles and all screens.
-func NewModel(a *app.App) Model {
+// The model subscribes to eng and to a's data changes until Close is called.
+func NewModel(a *app.App, eng *engine.Engine) Model {
 	styles := theme.DefaultStyles()
 	keys := DefaultKeyMap()
+	events, unsubscribeEvents := eng.Subscribe()
+	changes, unsubscribeChanges := a.Subscribe()
 
 	return Model{
-		app:    a,
-		styles: styles,
-		keys:   keys,
-		help:   NewHelpModel(keys, styles),
-		screen: ScreenHome,
-
-		home:               NewHomeScreen(a, styles),
+		app:     a,
+		engine:  eng,
+		events:  events,
+		changes: changes,
+		styles:  styles,
+		keys:    keys,
+		help:    NewHelpModel(keys, styles),
+		screen:  ScreenHome,
+
+		unsubscribeEvents:  unsubscribeEvents,
+		unsubscribeChanges: unsubscribeChanges,
+
+		home:               NewHomeScreen(a, eng, styles),
 		createCluster:      NewCreateClusterScreen(a, styles),
 		dashboard:          NewDashboardScreen(a, styles),
 		commanderDashboard: NewCommanderDashboardScreen(a, styles),
@@ -63,13 +79,20 @@ func NewModel(a *app.App) Model {
 		commanderChat:      NewCommanderChatScreen(a, styles),
 		crewManager:        NewCrewManagerScreen(a, styles),
 		newTask:            NewNewTaskScreen(a, styles),
-		commanderReview:    NewCommanderReviewScreen(a, styles),
-		executionView:      NewExecutionViewScreen(a, styles),
-		diffReview:         NewDiffReviewScreen(a, styles),
+		commanderReview:    NewCommanderReviewScreen(a, eng, styles),
+		executionView:      NewExecutionViewScreen(a, eng, styles),
+		diffReview:         NewDiffReviewScreen(a, eng, styles),
 		configEditor:       NewConfigEditorScreen(a, styles),
 	}
 }
 
+// Close ends the engine and data change subscriptions shared by the model
+// and its copies. Call it once the program has exited.
+func (m Model) Close() {
+	m.unsubscribeEvents()
+	m.unsubscribeChanges()
+}
+
 // ---------------------------------------------------------------------------
 // tea.Model interface
 // ---------------------------------------------------------------------------
@@ -80,9 +103,35 @@ func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		tea.EnterAltScreen,
 		initCmd,
+		waitForEngineEvent(m.events),
+		waitForChange(m.changes),
 	)
 }
 
+// waitForEngineEvent returns a command that blocks until the engine
+// publishes the next event. Model.Update re-issues it after each event.
+func waitForEngineEvent(events <-chan engine.Event) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-events
+		if !ok {
+			return nil
+		}
+		return EngineEventMsg{Event: ev}
+	}
+}
+
+// waitForChange returns a command that blocks until the next data change.
+// Model.Update re-issues it after each change.
+func waitForChange(changes <-chan db.Change) tea.Cmd {
+	return func() tea.Msg {
+		c, ok := <-changes
+		if !ok {
+			return nil
+		}
+		return DataChangedMsg{Change: c}
+	}
+}
+
 // Update handles all incoming messages by routing to the active screen
 // and processing global keys and navigation messages.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -165,6 +214,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(cmds...)
 
+	case EngineEventMsg:
+		// Engine events always reach the execution view, even when it is
+		// not the active screen, so its state is current when revisited.
+		var cmd tea.Cmd
+		m.executionView, cmd = m.executionView.Update(msg)
+		cmds = append(cmds, cmd, waitForEngineEvent(m.events))
+		return m, tea.Batch(cmds...)
+
+	case DataChangedMsg:
+		// Reload the lists on screen when another front-end, or the
+		// engine, changes what they show.
+		cmds = append(cmds, waitForChange(m.changes))
+		switch msg.Change.Kind {
+		case db.ChangeCrew, db.ChangeThread, db.ChangeTask, db.ChangeExecution, db.ChangeReview:
+			if m.screen == ScreenDashboard {
+				cmds = append(cmds, m.dashboard.Init())
+			}
+			if m.screen == ScreenCrewManager && msg.Change.Kind == db.ChangeCrew {
+				cmds = append(cmds, m.crewManager.Init())
+			}
+		case db.ChangeAgentRun:
+			if m.screen == ScreenDashboard {
+				cmds = append(cmds, m.dashboard.loadUsage())
+			}
+		}
+		return m, tea.Batch(cmds...)
+
 	case WebServerStartedMsg:
 		m.status = fmt.Sprintf("Web GUI running at %s", msg.URL)
 		return m, nil
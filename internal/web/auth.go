@@ -0,0 +1,92 @@
+package web
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// newToken returns a random per-session access token.
+func newToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("web: generate token: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+// requireAuth wraps the API and event stream so that every request must
+// carry the session token and mutating requests must come from the GUI's
+// own origin. The static GUI itself is served without a token; it reads the
+// token from the URL fragment it was opened with.
+func (s *Server) requireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/api/") && r.URL.Path != "/events" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !s.validToken(r) {
+			jsonError(w, http.StatusUnauthorized, "web: missing or invalid access token; open the URL printed by bore-tui")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			if !sameOrigin(r) {
+				jsonError(w, http.StatusForbidden, "web: cross-origin request refused")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validToken reports whether r carries the session token, as a bearer token
+// or, for the event streams (EventSource cannot set headers), as the token
+// query parameter.
+func (s *Server) validToken(r *http.Request) bool {
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok && isEventStream(r) {
+		got, ok = r.URL.Query().Get("token"), true
+	}
+	return ok && subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) == 1
+}
+
+// isEventStream reports whether r is for /events or an execution stream.
+func isEventStream(r *http.Request) bool {
+	return r.Method == http.MethodGet &&
+		(r.URL.Path == "/events" || strings.HasPrefix(r.URL.Path, "/api/executions/") && strings.HasSuffix(r.URL.Path, "/stream"))
+}
+
+// sameOrigin reports whether a request was not sent cross-origin by a
+// browser. Requests without an Origin header (curl, scripts) pass; they
+// still need the token.
+func sameOrigin(r *http.Request) bool {
+	if r.Header.Get("Sec-Fetch-Site") == "cross-site" {
+		return false
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// isLoopback reports whether host only reaches this machine. An empty host
+// means all interfaces.
+func isLoopback(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
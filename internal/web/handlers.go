@@ -11,14 +11,14 @@ import (
 	"time"
 
 	"bore-tui/internal/agents"
+	"bore-tui/internal/config"
 	"bore-tui/internal/db"
+	"bore-tui/internal/process"
 )
 
 // jsonOK writes v as a JSON 200 response.
 func jsonOK(w http.ResponseWriter, v any) {
 	w.Header().Set("Content-Type", "application/json")
-	// Allow localhost browser access; no auth on this server.
-	w.Header().Set("Access-Control-Allow-Origin", "*")
 	if err := json.NewEncoder(w).Encode(v); err != nil {
 		// response already started; can't write error header
 		_ = err
@@ -28,8 +28,6 @@ func jsonOK(w http.ResponseWriter, v any) {
 // jsonError writes a JSON error response with the given HTTP status code.
 func jsonError(w http.ResponseWriter, code int, msg string) {
 	w.Header().Set("Content-Type", "application/json")
-	// Allow localhost browser access; no auth on this server.
-	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.WriteHeader(code)
 	if err := json.NewEncoder(w).Encode(map[string]string{"error": msg}); err != nil {
 		// response already started; can't write error header
@@ -123,7 +121,6 @@ func (s *Server) handleOpenCluster(w http.ResponseWriter, r *http.Request) {
 		jsonError(w, http.StatusServiceUnavailable, fmt.Sprintf("web: open cluster: %s", err.Error()))
 		return
 	}
-	s.hub.emit("cluster_opened", `{}`)
 	jsonOK(w, map[string]bool{"ok": true})
 }
 
@@ -186,7 +183,6 @@ func (s *Server) handleCreateTask(w http.ResponseWriter, r *http.Request) {
 		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: create task: %s", err))
 		return
 	}
-	s.hub.emit("tasks_updated", "{}")
 	jsonOK(w, task)
 }
 
@@ -213,6 +209,224 @@ func (s *Server) handleGetTask(w http.ResponseWriter, r *http.Request) {
 	jsonOK(w, task)
 }
 
+// handleExecuteTask creates an execution and worktree for a task and starts
+// it in the engine. The Boss receives a minimal brief derived from the task.
+func (s *Server) handleExecuteTask(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1 MiB
+	d := s.requireDB(w)
+	if d == nil {
+		return
+	}
+	id, err := parseID(r)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var body struct {
+		BaseBranch string `json:"base_branch"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		jsonError(w, http.StatusBadRequest, fmt.Sprintf("web: execute task: decode: %s", err))
+		return
+	}
+
+	task, err := d.GetTask(r.Context(), id)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: execute task: get task: %s", err))
+		return
+	}
+	if task == nil {
+		jsonError(w, http.StatusNotFound, "task not found")
+		return
+	}
+	if task.Status == db.StatusRunning || task.Status == db.StatusAwaitingUser {
+		jsonError(w, http.StatusConflict, "web: execute task: task is already running")
+		return
+	}
+
+	exec, err := s.eng.Prepare(r.Context(), task, body.BaseBranch, nil)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: execute task: %s", err))
+		return
+	}
+	if err := s.eng.Start(exec.ID); err != nil {
+		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: execute task: %s", err))
+		return
+	}
+	jsonOK(w, exec)
+}
+
+// ---------------------------------------------------------------------------
+// Commander review
+// ---------------------------------------------------------------------------
+
+// reviewTask loads the task named by the "id" path value for a review step,
+// writing an error response and returning nil if it cannot be reviewed.
+func (s *Server) reviewTask(w http.ResponseWriter, r *http.Request, op string) *db.Task {
+	d := s.requireDB(w)
+	if d == nil {
+		return nil
+	}
+	id, err := parseID(r)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return nil
+	}
+	task, err := d.GetTask(r.Context(), id)
+	if err != nil {
+		jsonError(w, http.StatusNotFound, fmt.Sprintf("web: %s: get task: %s", op, err))
+		return nil
+	}
+	if task.Status == db.StatusRunning || task.Status == db.StatusAwaitingUser {
+		jsonError(w, http.StatusConflict, fmt.Sprintf("web: %s: task is already running", op))
+		return nil
+	}
+	return task
+}
+
+// handleGetTaskReview returns every recorded review phase of a task, oldest
+// first.
+func (s *Server) handleGetTaskReview(w http.ResponseWriter, r *http.Request) {
+	d := s.requireDB(w)
+	if d == nil {
+		return
+	}
+	id, err := parseID(r)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	reviews, err := d.GetTaskReviews(r.Context(), id)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: task review: %s", err))
+		return
+	}
+	if reviews == nil {
+		reviews = []db.TaskReview{}
+	}
+	jsonOK(w, reviews)
+}
+
+// handleReviewClarifications asks the Commander for clarifying questions.
+func (s *Server) handleReviewClarifications(w http.ResponseWriter, r *http.Request) {
+	task := s.reviewTask(w, r, "review clarifications")
+	if task == nil {
+		return
+	}
+	resp, err := s.eng.Clarify(r.Context(), task)
+	if err != nil {
+		jsonError(w, http.StatusBadGateway, fmt.Sprintf("web: review clarifications: %s", err))
+		return
+	}
+	jsonOK(w, resp)
+}
+
+// handleReviewOptions takes the answers to the clarifying questions, keyed
+// by question ID, and asks the Commander for execution options.
+func (s *Server) handleReviewOptions(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1 MiB
+	task := s.reviewTask(w, r, "review options")
+	if task == nil {
+		return
+	}
+	var body struct {
+		Answers map[string]string `json:"answers"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		jsonError(w, http.StatusBadRequest, fmt.Sprintf("web: review options: decode: %s", err))
+		return
+	}
+	if body.Answers == nil {
+		body.Answers = map[string]string{}
+	}
+	resp, err := s.eng.ProposeOptions(r.Context(), task, body.Answers)
+	if err != nil {
+		jsonError(w, http.StatusBadGateway, fmt.Sprintf("web: review options: %s", err))
+		return
+	}
+	jsonOK(w, resp)
+}
+
+// handleReviewBrief takes the chosen option and base branch and asks the
+// Commander for the execution brief.
+func (s *Server) handleReviewBrief(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1 MiB
+	task := s.reviewTask(w, r, "review brief")
+	if task == nil {
+		return
+	}
+	var body struct {
+		OptionID   string `json:"option_id"`
+		BaseBranch string `json:"base_branch"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		jsonError(w, http.StatusBadRequest, fmt.Sprintf("web: review brief: decode: %s", err))
+		return
+	}
+	if body.OptionID == "" || body.BaseBranch == "" {
+		jsonError(w, http.StatusBadRequest, "web: review brief: option_id and base_branch are required")
+		return
+	}
+	resp, err := s.eng.DraftBrief(r.Context(), task, body.OptionID, body.BaseBranch)
+	if err != nil {
+		jsonError(w, http.StatusBadGateway, fmt.Sprintf("web: review brief: %s", err))
+		return
+	}
+	jsonOK(w, resp)
+}
+
+// handleReviewApprove approves an execution brief, creates the execution and
+// its worktree, and starts it. Without a brief in the body the latest brief
+// recorded for the task is approved.
+func (s *Server) handleReviewApprove(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1 MiB
+	task := s.reviewTask(w, r, "review approve")
+	if task == nil {
+		return
+	}
+	var body struct {
+		Brief *agents.ExecutionBrief `json:"brief"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		jsonError(w, http.StatusBadRequest, fmt.Sprintf("web: review approve: decode: %s", err))
+		return
+	}
+	if body.Brief == nil {
+		reviews, err := s.a.DB().GetTaskReviews(r.Context(), task.ID)
+		if err != nil {
+			jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: review approve: %s", err))
+			return
+		}
+		for i := len(reviews) - 1; i >= 0 && body.Brief == nil; i-- {
+			if reviews[i].Phase != db.PhaseBrief {
+				continue
+			}
+			var brief agents.ExecutionBrief
+			if err := json.Unmarshal([]byte(reviews[i].Content), &brief); err != nil {
+				jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: review approve: decode recorded brief: %s", err))
+				return
+			}
+			body.Brief = &brief
+		}
+		if body.Brief == nil {
+			jsonError(w, http.StatusConflict, "web: review approve: no execution brief to approve")
+			return
+		}
+	}
+
+	exec, err := s.eng.ApproveBrief(r.Context(), task, *body.Brief)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: review approve: %s", err))
+		return
+	}
+	if err := s.eng.StartWithBrief(exec.ID, *body.Brief); err != nil {
+		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: review approve: %s", err))
+		return
+	}
+	jsonOK(w, exec)
+}
+
 // ---------------------------------------------------------------------------
 // Executions
 // ---------------------------------------------------------------------------
@@ -298,6 +512,172 @@ func (s *Server) handleListAgentRuns(w http.ResponseWriter, r *http.Request) {
 	jsonOK(w, runs)
 }
 
+// handleUsage returns the cluster's agent token usage and cost, grouped by
+// the "by" query parameter: execution, task, thread, crew or day (default).
+func (s *Server) handleUsage(w http.ResponseWriter, r *http.Request) {
+	d := s.requireDB(w)
+	if d == nil {
+		return
+	}
+	cluster := s.a.Cluster()
+	if cluster == nil {
+		jsonError(w, http.StatusServiceUnavailable, "web: no cluster open")
+		return
+	}
+	by := r.URL.Query().Get("by")
+	switch by {
+	case "":
+		by = db.UsageByDay
+	case db.UsageByExecution, db.UsageByTask, db.UsageByThread, db.UsageByCrew, db.UsageByDay:
+	default:
+		jsonError(w, http.StatusBadRequest, "web: usage: by must be one of execution, task, thread, crew, day")
+		return
+	}
+	usage, err := d.UsageBy(r.Context(), cluster.ID, by)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: usage: %s", err))
+		return
+	}
+	if usage == nil {
+		usage = []db.UsageRollup{}
+	}
+	jsonOK(w, usage)
+}
+
+// handleGetExecutionLive returns the engine's in-memory view of an
+// execution: current step, worker progress, pause state and recent output.
+// Executions the engine is not running report running=false.
+func (s *Server) handleGetExecutionLive(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	snap, _ := s.eng.Snapshot(id)
+	jsonOK(w, snap)
+}
+
+// handleStartExecution starts a pending execution in the engine.
+func (s *Server) handleStartExecution(w http.ResponseWriter, r *http.Request) {
+	if d := s.requireDB(w); d == nil {
+		return
+	}
+	id, err := parseID(r)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := s.eng.Start(id); err != nil {
+		jsonError(w, http.StatusConflict, fmt.Sprintf("web: start execution: %s", err))
+		return
+	}
+	jsonOK(w, map[string]bool{"ok": true})
+}
+
+// handlePauseExecution stops a running execution from launching new agents.
+func (s *Server) handlePauseExecution(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := s.eng.Pause(id); err != nil {
+		jsonError(w, http.StatusConflict, fmt.Sprintf("web: pause execution: %s", err))
+		return
+	}
+	jsonOK(w, map[string]bool{"ok": true})
+}
+
+// handleUnpauseExecution lets a paused execution continue.
+func (s *Server) handleUnpauseExecution(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := s.eng.Unpause(id); err != nil {
+		jsonError(w, http.StatusConflict, fmt.Sprintf("web: unpause execution: %s", err))
+		return
+	}
+	jsonOK(w, map[string]bool{"ok": true})
+}
+
+// handleCancelExecution stops a running execution, killing its agents. The
+// worktree is left intact for review.
+func (s *Server) handleCancelExecution(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := s.eng.Cancel(id); err != nil {
+		jsonError(w, http.StatusConflict, fmt.Sprintf("web: cancel execution: %s", err))
+		return
+	}
+	jsonOK(w, map[string]bool{"ok": true})
+}
+
+// handleResumeExecution resumes an interrupted or cancelled execution from
+// its persisted Boss plan, skipping workers that already completed.
+func (s *Server) handleResumeExecution(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := s.eng.Resume(id); err != nil {
+		jsonError(w, http.StatusConflict, fmt.Sprintf("web: resume execution: %s", err))
+		return
+	}
+	jsonOK(w, map[string]bool{"ok": true})
+}
+
+// handleListQuestions returns every question escalated during an execution,
+// open and answered, oldest first.
+func (s *Server) handleListQuestions(w http.ResponseWriter, r *http.Request) {
+	d := s.requireDB(w)
+	if d == nil {
+		return
+	}
+	id, err := parseID(r)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	questions, err := d.ListQuestions(r.Context(), id)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: list questions: %s", err))
+		return
+	}
+	if questions == nil {
+		questions = []db.ExecutionQuestion{}
+	}
+	jsonOK(w, questions)
+}
+
+// handleAnswerQuestion answers an open question and resumes the blocked
+// worker. An empty answer accepts the worker's result as is.
+func (s *Server) handleAnswerQuestion(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1 MiB
+	id, err := parseID(r)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var body struct {
+		Answer string `json:"answer"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		jsonError(w, http.StatusBadRequest, fmt.Sprintf("web: answer question: decode: %s", err))
+		return
+	}
+	if err := s.eng.Answer(id, strings.TrimSpace(body.Answer)); err != nil {
+		jsonError(w, http.StatusConflict, fmt.Sprintf("web: answer question: %s", err))
+		return
+	}
+	jsonOK(w, map[string]bool{"ok": true})
+}
+
 // ---------------------------------------------------------------------------
 // Diff actions
 // ---------------------------------------------------------------------------
@@ -400,52 +780,25 @@ func (s *Server) handleDiffCommit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	_ = d.UpdateTaskStatus(r.Context(), exec.TaskID, db.StatusCompleted)
-	s.hub.emit("executions_updated", "{}")
-	s.hub.emit("tasks_updated", "{}")
 	jsonOK(w, map[string]bool{"ok": true})
 }
 
 // handleDiffRevert reverts all changes in the execution worktree and marks
 // the execution as interrupted.
 func (s *Server) handleDiffRevert(w http.ResponseWriter, r *http.Request) {
-	r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1 MiB
-	d := s.requireDB(w)
-	if d == nil {
-		return
-	}
-	repo := s.a.Repo()
-	if repo == nil {
-		jsonError(w, http.StatusServiceUnavailable, "no repo available")
+	if d := s.requireDB(w); d == nil {
 		return
 	}
-
 	id, err := parseID(r)
 	if err != nil {
 		jsonError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	exec, err := d.GetExecution(r.Context(), id)
-	if err != nil {
-		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: diff revert: get execution: %s", err))
-		return
-	}
-	if exec == nil {
-		jsonError(w, http.StatusNotFound, "execution not found")
+	if err := s.eng.Revert(r.Context(), id); err != nil {
+		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: diff revert: %s", err))
 		return
 	}
-
-	if err := repo.Revert(r.Context(), exec.WorktreePath, true); err != nil {
-		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: diff revert: revert: %s", err))
-		return
-	}
-	if err := d.SetExecutionFinished(r.Context(), id, "interrupted"); err != nil {
-		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: diff revert: update status: %s", err))
-		return
-	}
-	_ = d.UpdateTaskStatus(r.Context(), exec.TaskID, db.StatusInterrupted)
-	s.hub.emit("executions_updated", "{}")
-	s.hub.emit("tasks_updated", "{}")
 	jsonOK(w, map[string]bool{"ok": true})
 }
 
@@ -456,58 +809,23 @@ func (s *Server) handleDiffMerge(w http.ResponseWriter, r *http.Request) {
 	if d == nil {
 		return
 	}
-	repo := s.a.Repo()
-	if repo == nil {
-		jsonError(w, http.StatusServiceUnavailable, "no repo available")
-		return
-	}
-
 	id, err := parseID(r)
 	if err != nil {
 		jsonError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-
 	exec, err := d.GetExecution(r.Context(), id)
 	if err != nil {
-		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: diff merge: get execution: %s", err))
-		return
-	}
-	if exec == nil {
-		jsonError(w, http.StatusNotFound, "execution not found")
+		jsonError(w, http.StatusNotFound, fmt.Sprintf("web: diff merge: get execution: %s", err))
 		return
 	}
 
-	if err := repo.AddAll(r.Context(), exec.WorktreePath); err != nil {
-		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: diff merge: add: %s", err))
-		return
-	}
-	commitMsg := fmt.Sprintf("bore-tui: execution #%d", exec.ID)
-	if err := repo.Commit(r.Context(), exec.WorktreePath, commitMsg); err != nil {
-		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: diff merge: commit: %s", err))
-		return
-	}
-
-	baseBranch := exec.BaseBranch
-	if baseBranch == "" {
-		baseBranch = "main"
-	}
-	if err := repo.MergeInto(r.Context(), baseBranch, exec.ExecBranch); err != nil {
-		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: diff merge: merge: %s", err))
-		return
-	}
-
-	if err := repo.RemoveWorktree(r.Context(), exec.WorktreePath); err != nil {
-		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: diff merge: remove worktree: %s", err))
+	baseBranch, err := s.eng.Merge(r.Context(), id)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: diff merge: %s", err))
 		return
 	}
-	_ = repo.DeleteBranch(r.Context(), exec.ExecBranch)
-	_ = repo.PruneWorktrees(r.Context())
-	_ = d.UpdateExecutionStatus(r.Context(), exec.ID, db.StatusCompleted)
-	_ = d.UpdateTaskStatus(r.Context(), exec.TaskID, db.StatusCompleted)
 
-	s.hub.emit("executions_updated", "{}")
-	s.hub.emit("tasks_updated", "{}")
 	jsonOK(w, map[string]any{
 		"ok":      true,
 		"message": fmt.Sprintf("Merged %s into %s. Worktree cleaned up.", exec.ExecBranch, baseBranch),
@@ -558,6 +876,7 @@ func (s *Server) handleCreateCrew(w http.ResponseWriter, r *http.Request) {
 		Constraints     string `json:"constraints"`
 		AllowedCommands string `json:"allowed_commands"`
 		OwnershipPaths  string `json:"ownership_paths"`
+		Model           string `json:"model"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		jsonError(w, http.StatusBadRequest, fmt.Sprintf("web: create crew: decode: %s", err))
@@ -568,12 +887,11 @@ func (s *Server) handleCreateCrew(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	crew, err := d.CreateCrew(r.Context(), clusterID, body.Name, body.Objective, body.Constraints, body.AllowedCommands, body.OwnershipPaths)
+	crew, err := d.CreateCrew(r.Context(), clusterID, body.Name, body.Objective, body.Constraints, body.AllowedCommands, body.OwnershipPaths, body.Model)
 	if err != nil {
 		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: create crew: %s", err))
 		return
 	}
-	s.hub.emit("crews_updated", "{}")
 	jsonOK(w, crew)
 }
 
@@ -597,6 +915,7 @@ func (s *Server) handleUpdateCrew(w http.ResponseWriter, r *http.Request) {
 		Constraints     string `json:"constraints"`
 		AllowedCommands string `json:"allowed_commands"`
 		OwnershipPaths  string `json:"ownership_paths"`
+		Model           string `json:"model"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		jsonError(w, http.StatusBadRequest, fmt.Sprintf("web: update crew: decode: %s", err))
@@ -618,12 +937,12 @@ func (s *Server) handleUpdateCrew(w http.ResponseWriter, r *http.Request) {
 	crew.Constraints = body.Constraints
 	crew.AllowedCommands = body.AllowedCommands
 	crew.OwnershipPaths = body.OwnershipPaths
+	crew.Model = body.Model
 
 	if err := d.UpdateCrew(r.Context(), crew); err != nil {
 		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: update crew: %s", err))
 		return
 	}
-	s.hub.emit("crews_updated", "{}")
 	jsonOK(w, crew)
 }
 
@@ -644,7 +963,6 @@ func (s *Server) handleDeleteCrew(w http.ResponseWriter, r *http.Request) {
 		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: delete crew: %s", err))
 		return
 	}
-	s.hub.emit("crews_updated", "{}")
 	jsonOK(w, map[string]bool{"ok": true})
 }
 
@@ -704,7 +1022,6 @@ func (s *Server) handleCreateThread(w http.ResponseWriter, r *http.Request) {
 		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: create thread: %s", err))
 		return
 	}
-	s.hub.emit("threads_updated", "{}")
 	jsonOK(w, thread)
 }
 
@@ -780,7 +1097,6 @@ func (s *Server) handleSaveBrain(w http.ResponseWriter, r *http.Request) {
 		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: save brain: %s", err.Error()))
 		return
 	}
-	s.hub.emit("brain_updated", `{}`)
 	jsonOK(w, map[string]bool{"ok": true})
 }
 
@@ -819,53 +1135,11 @@ func (s *Server) handleCommanderChat(w http.ResponseWriter, r *http.Request) {
 	clusterID := cluster.ID
 	ctx := r.Context()
 
-	brain, err := d.GetAllMemory(ctx, clusterID)
+	cmdCtx, err := agents.LoadCommanderContext(ctx, d, clusterID)
 	if err != nil {
-		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: commander chat: brain: %s", err))
+		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: commander chat: %s", err))
 		return
 	}
-	crews, err := d.ListCrews(ctx, clusterID)
-	if err != nil {
-		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: commander chat: crews: %s", err))
-		return
-	}
-	threads, err := d.ListThreads(ctx, clusterID)
-	if err != nil {
-		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: commander chat: threads: %s", err))
-		return
-	}
-	lessons, err := d.ListAllLessons(ctx, clusterID)
-	if err != nil {
-		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: commander chat: lessons: %s", err))
-		return
-	}
-	taskHistory, err := d.ListTaskHistories(ctx, clusterID)
-	if err != nil {
-		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: commander chat: task history: %s", err))
-		return
-	}
-
-	var pastRuns []db.AgentRun
-	if execs, err := d.ListExecutions(ctx, clusterID); err == nil {
-		limit := 5
-		if len(execs) < limit {
-			limit = len(execs)
-		}
-		for _, ex := range execs[:limit] {
-			if runs, err := d.GetAgentRuns(ctx, ex.ID); err == nil {
-				pastRuns = append(pastRuns, runs...)
-			}
-		}
-	}
-
-	cmdCtx := agents.CommanderContext{
-		Brain:       brain,
-		Crews:       crews,
-		Threads:     threads,
-		TaskHistory: taskHistory,
-		PastRuns:    pastRuns,
-		Lessons:     lessons,
-	}
 
 	systemPrompt := agents.BuildCommanderChatSystemPrompt(cmdCtx)
 	userMsg := agents.BuildCommanderChatMessage(req.History, req.Message)
@@ -877,13 +1151,13 @@ func (s *Server) handleCommanderChat(w http.ResponseWriter, r *http.Request) {
 		workDir = repo.Path
 	}
 
-	result := s.a.Runner().Run(context.Background(), workDir, fullPrompt, nil, nil, nil)
+	result := s.a.Backend(config.RoleCommander).RunWithOptions(context.Background(), workDir, fullPrompt, process.RunOptions{Permissions: process.ReadOnly()})
 	if result.Err != nil {
 		jsonError(w, http.StatusInternalServerError, fmt.Sprintf("web: commander chat: claude: %s", result.Err))
 		return
 	}
 
-	response := strings.TrimSpace(result.Stdout)
+	response := strings.TrimSpace(result.Text)
 	if response == "" {
 		response = "(no response)"
 	}
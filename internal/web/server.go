@@ -4,6 +4,7 @@ package web
 import (
 	"context"
 	"embed"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -11,9 +12,13 @@ import (
 	"net/http"
 	"os/exec"
 	"runtime"
+	"strconv"
+	"sync"
 	"time"
 
 	"bore-tui/internal/app"
+	"bore-tui/internal/db"
+	"bore-tui/internal/engine"
 )
 
 //go:embed static
@@ -23,40 +28,93 @@ const defaultPort = 8742
 
 // Server is the BORE web GUI HTTP server.
 type Server struct {
-	a    *app.App
-	srv  *http.Server
-	port int
-	hub  *sseHub
+	a     *app.App
+	eng   *engine.Engine
+	srv   *http.Server
+	host  string
+	port  int
+	hub   *sseHub
+	token string // per-session access token required by the API
+
+	stopOnce sync.Once
+	serveErr chan error
+}
+
+// StartOptions configures how a Server listens.
+type StartOptions struct {
+	// Addr is the host:port to listen on. If empty, the server listens on
+	// the loopback interface on the first free port from defaultPort
+	// upwards.
+	Addr string
+	// Expose must be set to listen on anything but a loopback address,
+	// including all interfaces (an Addr with an empty host).
+	Expose bool
+	// OpenBrowser opens the GUI in the system browser once listening.
+	OpenBrowser bool
 }
 
-// New creates a new Server bound to the given App.
-func New(a *app.App) *Server {
-	return &Server{a: a, hub: newSSEHub()}
+// New creates a new Server bound to the given App. Executions are started
+// and controlled through eng, which may be shared with other front-ends.
+func New(a *app.App, eng *engine.Engine) *Server {
+	return &Server{a: a, eng: eng, hub: newSSEHub(), token: newToken(), serveErr: make(chan error, 1)}
 }
 
+// Token returns the access token API clients must send as a bearer token.
+func (s *Server) Token() string { return s.token }
+
 // Port returns the port the server is listening on (0 if not started).
 func (s *Server) Port() int { return s.port }
 
-// URL returns the base URL (e.g., "http://localhost:8742").
+// URL returns the base URL (e.g., "http://127.0.0.1:8742").
 func (s *Server) URL() string {
-	return fmt.Sprintf("http://localhost:%d", s.port)
+	host := s.host
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, strconv.Itoa(s.port))
 }
 
-// Start binds to a free port starting at defaultPort, starts the HTTP server
-// in a background goroutine, and opens the browser. Returns the URL.
+// Start binds to a free loopback port starting at defaultPort, starts the
+// HTTP server in a background goroutine, and opens the browser. It returns
+// the URL to open the GUI with, which carries the access token in its
+// fragment.
 func (s *Server) Start(ctx context.Context) (string, error) {
-	ln, err := freePort(defaultPort)
+	return s.StartWithOptions(ctx, StartOptions{OpenBrowser: true})
+}
+
+// StartWithOptions is like Start but listens where opts says and only opens
+// the browser if asked to.
+func (s *Server) StartWithOptions(ctx context.Context, opts StartOptions) (string, error) {
+	var ln net.Listener
+	var err error
+	if opts.Addr == "" {
+		ln, err = freePort(defaultPort)
+	} else {
+		host, _, serr := net.SplitHostPort(opts.Addr)
+		if serr != nil {
+			return "", fmt.Errorf("web: start: %w", serr)
+		}
+		if !isLoopback(host) && !opts.Expose {
+			return "", fmt.Errorf("web: start: refusing to listen on non-loopback address %q unless exposing is requested", opts.Addr)
+		}
+		ln, err = net.Listen("tcp", opts.Addr)
+	}
 	if err != nil {
-		return "", fmt.Errorf("web: start: find port: %w", err)
+		return "", fmt.Errorf("web: start: listen: %w", err)
+	}
+	addr := ln.Addr().(*net.TCPAddr)
+	s.port = addr.Port
+	s.host = addr.IP.String()
+	if opts.Addr != "" {
+		s.host, _, _ = net.SplitHostPort(opts.Addr)
 	}
-	s.port = ln.Addr().(*net.TCPAddr).Port
 
 	mux := http.NewServeMux()
 	s.registerRoutes(mux)
 
 	s.srv = &http.Server{
-		Addr:              fmt.Sprintf(":%d", s.port),
-		Handler:           mux,
+		Addr:              addr.String(),
+		Handler:           s.requireAuth(mux),
 		ReadTimeout:       30 * time.Second,
 		ReadHeaderTimeout: 5 * time.Second,
 		// WriteTimeout intentionally 0 — SSE connections must not time out.
@@ -64,26 +122,35 @@ func (s *Server) Start(ctx context.Context) (string, error) {
 
 	go func() {
 		if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			// error is non-fatal after Stop() is called; log to stderr in debug builds
-			_ = err
+			s.serveErr <- fmt.Errorf("web: serve: %w", err)
 		}
+		close(s.serveErr)
 	}()
 	go s.hub.run()
+	go s.forwardChanges()
+	go s.forwardEngineEvents()
 
-	url := s.URL()
-	_ = openBrowser(ctx, url)
+	url := s.URL() + "/#token=" + s.token
+	if opts.OpenBrowser {
+		_ = openBrowser(ctx, url)
+	}
 	return url, nil
 }
 
-// Stop gracefully shuts down the server.
+// Done returns a channel that yields the error that stopped the server, if
+// any, and is closed once the server is no longer serving.
+func (s *Server) Done() <-chan error { return s.serveErr }
+
+// Stop gracefully shuts down the server. Open event streams are closed
+// first so that Shutdown does not wait on them until ctx expires.
 func (s *Server) Stop(ctx context.Context) error {
 	if s.srv == nil {
 		return nil
 	}
+	s.stopOnce.Do(func() { close(s.hub.quit) })
 	if err := s.srv.Shutdown(ctx); err != nil {
 		return fmt.Errorf("web: stop: %w", err)
 	}
-	close(s.hub.quit)
 	return nil
 }
 
@@ -106,12 +173,34 @@ func (s *Server) registerRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/tasks", s.handleListTasks)
 	mux.HandleFunc("POST /api/tasks", s.handleCreateTask)
 	mux.HandleFunc("GET /api/tasks/{id}", s.handleGetTask)
+	mux.HandleFunc("POST /api/tasks/{id}/execute", s.handleExecuteTask)
+
+	// Commander review
+	mux.HandleFunc("GET /api/tasks/{id}/review", s.handleGetTaskReview)
+	mux.HandleFunc("POST /api/tasks/{id}/review/clarifications", s.handleReviewClarifications)
+	mux.HandleFunc("POST /api/tasks/{id}/review/options", s.handleReviewOptions)
+	mux.HandleFunc("POST /api/tasks/{id}/review/brief", s.handleReviewBrief)
+	mux.HandleFunc("POST /api/tasks/{id}/review/approve", s.handleReviewApprove)
 
 	// Executions
 	mux.HandleFunc("GET /api/executions", s.handleListExecutions)
 	mux.HandleFunc("GET /api/executions/{id}", s.handleGetExecution)
 	mux.HandleFunc("GET /api/executions/{id}/events", s.handleListEvents)
 	mux.HandleFunc("GET /api/executions/{id}/runs", s.handleListAgentRuns)
+	mux.HandleFunc("GET /api/executions/{id}/live", s.handleGetExecutionLive)
+	mux.HandleFunc("GET /api/executions/{id}/stream", s.handleExecutionStream)
+	mux.HandleFunc("POST /api/executions/{id}/start", s.handleStartExecution)
+	mux.HandleFunc("POST /api/executions/{id}/pause", s.handlePauseExecution)
+	mux.HandleFunc("POST /api/executions/{id}/unpause", s.handleUnpauseExecution)
+	mux.HandleFunc("POST /api/executions/{id}/cancel", s.handleCancelExecution)
+	mux.HandleFunc("POST /api/executions/{id}/resume", s.handleResumeExecution)
+	mux.HandleFunc("GET /api/executions/{id}/questions", s.handleListQuestions)
+
+	// Usage and cost
+	mux.HandleFunc("GET /api/usage", s.handleUsage)
+
+	// Escalated questions (alert_with_issues mode)
+	mux.HandleFunc("POST /api/questions/{id}/answer", s.handleAnswerQuestion)
 
 	// Diff actions
 	mux.HandleFunc("GET /api/diff/{id}", s.handleGetDiff)
@@ -140,11 +229,82 @@ func (s *Server) registerRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/branches", s.handleListBranches)
 }
 
-// freePort finds the first available TCP port starting from start and returns
-// the bound listener. The caller is responsible for using or closing it.
+// changeEvents maps data change kinds to the SSE events the GUI reloads on.
+var changeEvents = map[db.ChangeKind]string{
+	db.ChangeCluster:   "cluster_opened",
+	db.ChangeCrew:      "crews_updated",
+	db.ChangeThread:    "threads_updated",
+	db.ChangeTask:      "tasks_updated",
+	db.ChangeExecution: "executions_updated",
+	db.ChangeAgentRun:  "executions_updated",
+	db.ChangeQuestion:  "executions_updated",
+	db.ChangePlan:      "executions_updated",
+	db.ChangeResult:    "executions_updated",
+	db.ChangeLesson:    "executions_updated",
+	db.ChangeReview:    "tasks_updated",
+	db.ChangeEvent:     "execution_event",
+}
+
+// forwardChanges broadcasts the app's data changes, whichever front-end or
+// the engine made them, to SSE clients until the hub shuts down. The event
+// data is the db.Change.
+func (s *Server) forwardChanges() {
+	changes, unsubscribe := s.a.Subscribe()
+	defer unsubscribe()
+	for {
+		select {
+		case c, ok := <-changes:
+			if !ok {
+				return
+			}
+			event := changeEvents[c.Kind]
+			if c.Kind == db.ChangeMemory && c.Key == "__brain__" {
+				event = "brain_updated"
+			}
+			if event == "" {
+				continue
+			}
+			if data, err := json.Marshal(c); err == nil {
+				s.hub.emit(event, string(data))
+			}
+		case <-s.hub.quit:
+			return
+		}
+	}
+}
+
+// forwardEngineEvents relays the engine's in-memory progress, which is not
+// stored and so never reaches the change bus, to SSE clients until the hub
+// shuts down.
+func (s *Server) forwardEngineEvents() {
+	events, unsubscribe := s.eng.Subscribe()
+	defer unsubscribe()
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			switch ev.Kind {
+			case engine.EventStep, engine.EventPaused, engine.EventUnpaused:
+				s.hub.emit("executions_updated", "{}")
+			case engine.EventOutput, engine.EventAgentOutput:
+				if data, err := json.Marshal(ev); err == nil {
+					s.hub.emit("execution_output", string(data))
+				}
+			}
+		case <-s.hub.quit:
+			return
+		}
+	}
+}
+
+// freePort finds the first available loopback TCP port starting from start
+// and returns the bound listener. The caller is responsible for using or
+// closing it.
 func freePort(start int) (net.Listener, error) {
 	for p := start; p < start+100; p++ {
-		ln, err := net.Listen("tcp", fmt.Sprintf(":%d", p))
+		ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", p))
 		if err == nil {
 			return ln, nil
 		}
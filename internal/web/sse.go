@@ -1,9 +1,13 @@
 package web
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
+
+	"bore-tui/internal/engine"
 )
 
 // sseHub manages Server-Sent Events clients.
@@ -20,7 +24,7 @@ func newSSEHub() *sseHub {
 	return &sseHub{
 		subscribe:   make(chan chan string, 8),
 		unsubscribe: make(chan chan string, 8),
-		broadcast:   make(chan string, 64),
+		broadcast:   make(chan string, 256),
 		quit:        make(chan struct{}),
 		clients:     make(map[chan string]struct{}),
 	}
@@ -79,8 +83,6 @@ func (s *Server) handleSSE(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
-	// Allow localhost browser access; no auth on this server.
-	w.Header().Set("Access-Control-Allow-Origin", "*")
 
 	flusher, ok := w.(http.Flusher)
 	if !ok {
@@ -88,9 +90,18 @@ func (s *Server) handleSSE(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ch := make(chan string, 32)
-	s.hub.subscribe <- ch
-	defer func() { s.hub.unsubscribe <- ch }()
+	ch := make(chan string, 128)
+	select {
+	case s.hub.subscribe <- ch:
+	case <-s.hub.quit:
+		return
+	}
+	defer func() {
+		select {
+		case s.hub.unsubscribe <- ch:
+		case <-s.hub.quit:
+		}
+	}()
 
 	if _, err := fmt.Fprintf(w, "event: connected\ndata: {}\n\n"); err != nil {
 		return
@@ -107,6 +118,162 @@ func (s *Server) handleSSE(w http.ResponseWriter, r *http.Request) {
 			flusher.Flush()
 		case <-r.Context().Done():
 			return
+		case <-s.hub.quit:
+			return
+		}
+	}
+}
+
+// streamPoll is how often an execution stream checks the database for
+// events and status changes made outside this process's engine.
+const streamPoll = 2 * time.Second
+
+// handleExecutionStream is the HTTP handler for
+// GET /api/executions/{id}/stream. It replays the execution's stored events
+// after the client's Last-Event-ID (or the "after" query parameter), then
+// streams new stored events, the engine's live output and status changes.
+//
+// Stored events are sent as "log" events whose SSE id is their
+// execution_events ID, so a reconnecting EventSource resumes where it left
+// off. Live engine events are sent under their kind ("output",
+// "agent_output", "step", ...) without an id; they are not stored, so a
+// "snapshot" event with the engine's recent output is sent on every
+// connect instead. "status" is sent on connect and whenever the execution's
+// status changes.
+func (s *Server) handleExecutionStream(w http.ResponseWriter, r *http.Request) {
+	d := s.requireDB(w)
+	if d == nil {
+		return
+	}
+	id, err := parseID(r)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	lastID, err := lastEventID(r)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	exec, err := d.GetExecution(r.Context(), id)
+	if err != nil {
+		jsonError(w, http.StatusNotFound, fmt.Sprintf("web: execution stream: %s", err))
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	// Subscribe before replaying so nothing published in between is missed.
+	events, unsubscribe := s.eng.Subscribe()
+	defer unsubscribe()
+
+	send := func(event string, id int64, v any) bool {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return true
+		}
+		if id > 0 {
+			_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, event, data)
+		} else {
+			_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+		}
+		return err == nil
+	}
+
+	status := ""
+	// catchUp sends stored events after lastID and the status if it changed.
+	catchUp := func() bool {
+		evs, err := d.ListEventsAfter(r.Context(), id, lastID)
+		if err != nil {
+			return r.Context().Err() == nil
 		}
+		for _, ev := range evs {
+			if !send("log", ev.ID, ev) {
+				return false
+			}
+			lastID = ev.ID
+		}
+		if exec, err = d.GetExecution(r.Context(), id); err == nil && exec.Status != status {
+			status = exec.Status
+			if !send("status", 0, map[string]any{"execution_id": id, "status": status}) {
+				return false
+			}
+		}
+		flusher.Flush()
+		return true
+	}
+
+	snap, _ := s.eng.Snapshot(id)
+	if !send("snapshot", 0, snap) || !catchUp() {
+		return
+	}
+
+	ticker := time.NewTicker(streamPoll)
+	defer ticker.Stop()
+	idle := 0
+	for {
+		select {
+		case ev, open := <-events:
+			if !open {
+				return
+			}
+			if ev.ExecutionID != id {
+				continue
+			}
+			if !send(string(ev.Kind), 0, ev) {
+				return
+			}
+			switch ev.Kind {
+			case engine.EventOutput, engine.EventAgentOutput:
+				flusher.Flush()
+			default:
+				// Lifecycle events usually come with stored events or a
+				// status change; pick them up now rather than on the tick.
+				if !catchUp() {
+					return
+				}
+			}
+			idle = 0
+		case <-ticker.C:
+			if !catchUp() {
+				return
+			}
+			if idle++; idle >= int(15*time.Second/streamPoll) {
+				if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+					return
+				}
+				flusher.Flush()
+				idle = 0
+			}
+		case <-r.Context().Done():
+			return
+		case <-s.hub.quit:
+			return
+		}
+	}
+}
+
+// lastEventID returns the ID of the last stored event a stream client has
+// seen: the Last-Event-ID header sent by a reconnecting EventSource, or the
+// "after" query parameter for the first connection. Zero replays everything.
+func lastEventID(r *http.Request) (int64, error) {
+	v := r.Header.Get("Last-Event-ID")
+	if v == "" {
+		v = r.URL.Query().Get("after")
+	}
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("web: invalid last event id %q", v)
 	}
+	return n, nil
 }